@@ -0,0 +1,73 @@
+package tickets
+
+/*
+Aggregator is an online version of the batch functions of this package. It keeps
+running totals that are updated every time a new ticket is added, so the statistics
+of a continuously growing set of tickets (e.g. a live feed) can be obtained without
+recomputing over the whole slice.
+
+An Aggregator must be created with NewAggregator. It is not safe for concurrent use.
+*/
+type Aggregator struct {
+	totalTickets      int
+	totalRevenue      int
+	periodCounts      map[string]int
+	destinationCounts map[string]int
+}
+
+// NewAggregator returns an empty Aggregator ready to receive tickets.
+func NewAggregator() *Aggregator {
+	return &Aggregator{
+		periodCounts:      newPeriodCounts(),
+		destinationCounts: map[string]int{},
+	}
+}
+
+// Add updates the running totals of the aggregator with the specified ticket.
+func (a *Aggregator) Add(ticket Ticket) {
+	a.totalTickets++
+	a.totalRevenue += ticket.ticketPrice
+	a.destinationCounts[ticket.destination]++
+
+	if period, ok := getPeriod(ticket.departureTime); ok {
+		a.periodCounts[period]++
+	}
+}
+
+// TotalTickets returns the number of tickets added so far.
+func (a *Aggregator) TotalTickets() int {
+	return a.totalTickets
+}
+
+// TotalRevenue returns the sum of the prices of all the tickets added so far.
+func (a *Aggregator) TotalRevenue() int {
+	return a.totalRevenue
+}
+
+/*
+PeriodCounts returns the number of tickets added so far for each period. The keys of
+the returned map are the same used by GetCountByPeriod.
+
+The returned map is a copy, so modifying it does not affect the aggregator.
+*/
+func (a *Aggregator) PeriodCounts() map[string]int {
+	return copyCounts(a.periodCounts)
+}
+
+/*
+DestinationCounts returns the number of tickets added so far for each destination.
+
+The returned map is a copy, so modifying it does not affect the aggregator.
+*/
+func (a *Aggregator) DestinationCounts() map[string]int {
+	return copyCounts(a.destinationCounts)
+}
+
+// copyCounts is a utility function that returns a copy of the specified counts map.
+func copyCounts(counts map[string]int) map[string]int {
+	result := make(map[string]int, len(counts))
+	for key, count := range counts {
+		result[key] = count
+	}
+	return result
+}
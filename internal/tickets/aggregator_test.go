@@ -0,0 +1,64 @@
+package tickets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregator(t *testing.T) {
+	t.Run("Empty aggregator", func(t *testing.T) {
+		aggregator := NewAggregator()
+
+		expectedPeriodCounts := map[string]int{
+			"morning":       0,
+			"afternoon":     0,
+			"evening":       0,
+			"early_morning": 0,
+		}
+
+		assert.Equal(t, 0, aggregator.TotalTickets())
+		assert.Equal(t, 0, aggregator.TotalRevenue())
+		assert.Equal(t, expectedPeriodCounts, aggregator.PeriodCounts())
+		assert.Empty(t, aggregator.DestinationCounts())
+	})
+
+	t.Run("Add tickets one at a time", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		aggregator := NewAggregator()
+
+		expectedRevenue := 0
+		for i, ticket := range ticketSlice {
+			aggregator.Add(ticket)
+			expectedRevenue += ticket.ticketPrice
+
+			// The online results must match the batch results for the tickets added so far
+			expectedPeriodCounts, _ := GetCountByPeriod(ticketSlice[:i+1])
+			assert.Equal(t, expectedPeriodCounts, aggregator.PeriodCounts())
+			assert.Equal(t, i+1, aggregator.TotalTickets())
+			assert.Equal(t, expectedRevenue, aggregator.TotalRevenue())
+		}
+
+		destinationCounts := aggregator.DestinationCounts()
+		for destination, count := range destinationCounts {
+			expectedCount, err := GetTotalTicketsByDestination(ticketSlice, destination)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedCount, count)
+		}
+		assert.Len(t, destinationCounts, 3)
+	})
+
+	t.Run("Returned maps are copies", func(t *testing.T) {
+		filename := "./ticket_test.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		aggregator := NewAggregator()
+		aggregator.Add(ticketSlice[0])
+
+		aggregator.PeriodCounts()["afternoon"] = 100
+		aggregator.DestinationCounts()["Finland"] = 100
+
+		assert.Equal(t, 1, aggregator.PeriodCounts()["afternoon"])
+		assert.Equal(t, 1, aggregator.DestinationCounts()["Finland"])
+	})
+}
@@ -0,0 +1,132 @@
+package tickets
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// CleanOptions holds the plausibility limits used by CleanTickets to drop tickets.
+type CleanOptions struct {
+	// EarliestDeparture and LatestDeparture drop the tickets whose departure time of day
+	// is outside the window between them (both inclusive). If both are zero, no ticket is
+	// dropped because of its departure time.
+	EarliestDeparture time.Time
+	LatestDeparture   time.Time
+
+	// MinPrice and MaxPrice drop the tickets whose price is outside the range between them
+	// (both inclusive). A MaxPrice of 0 means there is no upper limit, so the zero value
+	// only drops tickets with a negative price.
+	MinPrice int
+	MaxPrice int
+}
+
+// CleanReport describes the changes made by CleanTickets.
+type CleanReport struct {
+	// TrimmedFields is the number of name, email and destination fields that had
+	// leading or trailing spaces.
+	TrimmedFields int
+
+	// LowercasedEmails is the number of emails that contained upper case letters.
+	LowercasedEmails int
+
+	// NormalizedDestinations is the number of destinations whose spacing or case changed.
+	NormalizedDestinations int
+
+	// DroppedByPrice is the number of tickets dropped because of their price.
+	DroppedByPrice int
+
+	// DroppedByTime is the number of tickets dropped because of their departure time.
+	DroppedByTime int
+}
+
+/*
+normalizeDestination is a utility function that collapses the spaces of the specified
+destination and upper-cases the first letter of each of its words, leaving the rest of the
+letters as written so acronyms are kept (e.g. "  czech   republic" is converted to "Czech
+Republic" and "usa" to "Usa", but "USA" stays "USA").
+*/
+func normalizeDestination(destination string) string {
+	words := strings.Fields(destination)
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
+
+/*
+CleanTickets runs the whole cleanup pipeline over the specified tickets and returns the
+cleaned tickets together with a report of what changed. The steps are applied in order:
+
+ 1. Leading and trailing spaces are trimmed from the name, email and destination.
+ 2. Emails are converted to lower case.
+ 3. Destinations are normalized: inner spaces are collapsed and the first letter of each
+    word is upper-cased.
+ 4. Tickets priced outside the limits of the options are dropped.
+ 5. Tickets departing outside the window of the options are dropped.
+
+The specified slice is not modified. It returns an error if the data is empty or if the
+options contain an inverted price range or time window.
+*/
+func CleanTickets(data []Ticket, opts CleanOptions) ([]Ticket, CleanReport, error) {
+	var report CleanReport
+
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, report, ErrEmptyData
+	}
+
+	// If the price range is inverted, return an error
+	if opts.MaxPrice != 0 && opts.MinPrice > opts.MaxPrice {
+		return nil, report, errors.New("minimum price must not be greater than maximum price")
+	}
+
+	cleaned := make([]Ticket, 0, len(data))
+	for _, ticket := range data {
+		// Trim the string fields
+		for _, field := range []*string{&ticket.name, &ticket.email, &ticket.destination} {
+			if trimmed := strings.TrimSpace(*field); trimmed != *field {
+				*field = trimmed
+				report.TrimmedFields++
+			}
+		}
+
+		// Convert the email to lower case
+		if lowercased := strings.ToLower(ticket.email); lowercased != ticket.email {
+			ticket.email = lowercased
+			report.LowercasedEmails++
+		}
+
+		// Normalize the destination
+		if normalized := normalizeDestination(ticket.destination); normalized != ticket.destination {
+			ticket.destination = normalized
+			report.NormalizedDestinations++
+		}
+
+		// Drop the ticket if its price is implausible
+		if ticket.ticketPrice < opts.MinPrice || (opts.MaxPrice != 0 && ticket.ticketPrice > opts.MaxPrice) {
+			report.DroppedByPrice++
+			continue
+		}
+
+		cleaned = append(cleaned, ticket)
+	}
+
+	// Drop the tickets departing at implausible times
+	if !opts.EarliestDeparture.IsZero() || !opts.LatestDeparture.IsZero() {
+		var err error
+		cleaned, report.DroppedByTime, err = RemoveImplausibleTimes(
+			cleaned,
+			opts.EarliestDeparture,
+			opts.LatestDeparture,
+		)
+		if err != nil {
+			return nil, CleanReport{}, err
+		}
+	}
+
+	return cleaned, report, nil
+}
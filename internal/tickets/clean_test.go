@@ -0,0 +1,135 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanTickets(t *testing.T) {
+	departureTime, _ := time.Parse("15:04", "17:11")
+	cleanTicket := Ticket{1, "Tait Mc Caughan", "tmc0@scribd.com", "Finland", departureTime, 785}
+
+	t.Run("Clean empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		cleaned, report, err := CleanTickets(ticketSlice, CleanOptions{})
+
+		assert.Nil(t, cleaned)
+		assert.Equal(t, CleanReport{}, report)
+		assert.Error(t, err)
+	})
+
+	t.Run("Clean ticket slice without changes", func(t *testing.T) {
+		ticketSlice := []Ticket{cleanTicket}
+
+		cleaned, report, err := CleanTickets(ticketSlice, CleanOptions{})
+
+		assert.Equal(t, ticketSlice, cleaned)
+		assert.Equal(t, CleanReport{}, report)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Trim string fields", func(t *testing.T) {
+		dirtyTicket := cleanTicket
+		dirtyTicket.name = "  Tait Mc Caughan "
+		dirtyTicket.email = "tmc0@scribd.com\t"
+		ticketSlice := []Ticket{dirtyTicket}
+
+		cleaned, report, err := CleanTickets(ticketSlice, CleanOptions{})
+
+		assert.Equal(t, []Ticket{cleanTicket}, cleaned)
+		assert.Equal(t, CleanReport{TrimmedFields: 2}, report)
+		assert.Equal(t, "  Tait Mc Caughan ", ticketSlice[0].name)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Lowercase emails", func(t *testing.T) {
+		dirtyTicket := cleanTicket
+		dirtyTicket.email = "TMC0@Scribd.com"
+
+		cleaned, report, err := CleanTickets([]Ticket{dirtyTicket}, CleanOptions{})
+
+		assert.Equal(t, []Ticket{cleanTicket}, cleaned)
+		assert.Equal(t, CleanReport{LowercasedEmails: 1}, report)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Normalize destinations", func(t *testing.T) {
+		dirtyTicket := cleanTicket
+		dirtyTicket.destination = "czech   republic"
+		expectedTicket := cleanTicket
+		expectedTicket.destination = "Czech Republic"
+
+		cleaned, report, err := CleanTickets([]Ticket{dirtyTicket}, CleanOptions{})
+
+		assert.Equal(t, []Ticket{expectedTicket}, cleaned)
+		assert.Equal(t, CleanReport{NormalizedDestinations: 1}, report)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Keep acronyms in destinations", func(t *testing.T) {
+		dirtyTicket := cleanTicket
+		dirtyTicket.destination = " new  york USA"
+		acronymTicket := cleanTicket
+		acronymTicket.destination = "USA"
+		expectedTicket := cleanTicket
+		expectedTicket.destination = "New York USA"
+
+		cleaned, report, err := CleanTickets([]Ticket{dirtyTicket, acronymTicket}, CleanOptions{})
+
+		assert.Equal(t, []Ticket{expectedTicket, acronymTicket}, cleaned)
+		assert.Equal(t, CleanReport{TrimmedFields: 1, NormalizedDestinations: 1}, report)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Drop implausible prices", func(t *testing.T) {
+		negativePrice := cleanTicket
+		negativePrice.ticketPrice = -100
+		expensivePrice := cleanTicket
+		expensivePrice.ticketPrice = 100000
+		ticketSlice := []Ticket{cleanTicket, negativePrice, expensivePrice}
+
+		cleaned, report, err := CleanTickets(ticketSlice, CleanOptions{MaxPrice: 5000})
+
+		assert.Equal(t, []Ticket{cleanTicket}, cleaned)
+		assert.Equal(t, CleanReport{DroppedByPrice: 2}, report)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Inverted price range", func(t *testing.T) {
+		opts := CleanOptions{MinPrice: 500, MaxPrice: 100}
+
+		cleaned, _, err := CleanTickets([]Ticket{cleanTicket}, opts)
+
+		assert.Nil(t, cleaned)
+		assert.Error(t, err)
+	})
+
+	t.Run("Drop implausible times", func(t *testing.T) {
+		earlyTicket := cleanTicket
+		earlyTicket.departureTime, _ = time.Parse("15:04", "03:00")
+		earliest, _ := time.Parse("15:04", "05:00")
+		latest, _ := time.Parse("15:04", "23:00")
+		opts := CleanOptions{EarliestDeparture: earliest, LatestDeparture: latest}
+
+		cleaned, report, err := CleanTickets([]Ticket{cleanTicket, earlyTicket}, opts)
+
+		assert.Equal(t, []Ticket{cleanTicket}, cleaned)
+		assert.Equal(t, CleanReport{DroppedByTime: 1}, report)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Inverted time window", func(t *testing.T) {
+		earliest, _ := time.Parse("15:04", "23:00")
+		latest, _ := time.Parse("15:04", "05:00")
+		opts := CleanOptions{EarliestDeparture: earliest, LatestDeparture: latest}
+
+		cleaned, report, err := CleanTickets([]Ticket{cleanTicket}, opts)
+
+		assert.Nil(t, cleaned)
+		assert.Equal(t, CleanReport{}, report)
+		assert.Error(t, err)
+	})
+}
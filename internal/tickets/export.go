@@ -0,0 +1,205 @@
+package tickets
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ticketJSON is the JSON representation of a Ticket.
+type ticketJSON struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	Destination   string `json:"destination"`
+	DepartureTime string `json:"departure_time"`
+	TicketPrice   int    `json:"ticket_price"`
+}
+
+/*
+formatDepartureTime is a utility function that formats the specified departure time in
+the same way it is written in the CSV files: as HH:MM if it has no date (year 0) and as
+an RFC3339 timestamp otherwise, so parseDepartureTime can read it back.
+*/
+func formatDepartureTime(departureTime time.Time) string {
+	if departureTime.Year() == 0 {
+		return departureTime.Format("15:04")
+	}
+	return departureTime.Format(time.RFC3339)
+}
+
+/*
+MarshalJSON implements the json.Marshaler interface. The ticket is encoded as a JSON
+object with the same keys as the CSV columns, and the departure time is formatted
+as HH:MM (or as an RFC3339 timestamp if it has a date).
+*/
+func (t Ticket) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ticketJSON{
+		ID:            t.id,
+		Name:          t.name,
+		Email:         t.email,
+		Destination:   t.destination,
+		DepartureTime: formatDepartureTime(t.departureTime),
+		TicketPrice:   t.ticketPrice,
+	})
+}
+
+/*
+UnmarshalJSON implements the json.Unmarshaler interface. It decodes the objects written
+by MarshalJSON, accepting the same departure time formats as the CSV files.
+*/
+func (t *Ticket) UnmarshalJSON(encoded []byte) error {
+	var record ticketJSON
+	if err := json.Unmarshal(encoded, &record); err != nil {
+		return err
+	}
+
+	departureTime, err := parseDepartureTime(record.DepartureTime)
+	if err != nil {
+		return err
+	}
+
+	*t = Ticket{
+		id:            record.ID,
+		name:          record.Name,
+		email:         record.Email,
+		destination:   record.Destination,
+		departureTime: departureTime,
+		ticketPrice:   record.TicketPrice,
+	}
+	return nil
+}
+
+/*
+TicketsJSON encodes the specified tickets as a JSON array. If indent is true, the output
+is pretty-printed using two spaces per level.
+
+An empty or nil slice is treated as missing data, so it returns an error instead of an
+empty array.
+*/
+func TicketsJSON(data []Ticket, indent bool) ([]byte, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	if indent {
+		return json.MarshalIndent(data, "", "  ")
+	}
+	return json.Marshal(data)
+}
+
+/*
+ExportToJSON writes the specified tickets to w as a JSON array, using the same object
+format as TicketsJSON. The output can be decoded back into a []Ticket with json.Unmarshal.
+
+An empty or nil slice is treated as missing data, so it returns an error and nothing is
+written.
+*/
+func ExportToJSON(data []Ticket, w io.Writer) error {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return ErrEmptyData
+	}
+
+	return json.NewEncoder(w).Encode(data)
+}
+
+/*
+ExportToCSV writes the specified tickets to w in the same CSV format read by
+ExtractTicketData: a header row (see HeaderRow) followed by one record per ticket. The
+fields are quoted when needed and the departure time is formatted as HH:MM (or as an
+RFC3339 timestamp if it has a date), so the output can be extracted again.
+
+An empty or nil slice is treated as missing data, so it returns an error and nothing is
+written.
+*/
+func ExportToCSV(data []Ticket, w io.Writer) error {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return ErrEmptyData
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(HeaderRow()); err != nil {
+		return err
+	}
+
+	// Write one record per ticket
+	for _, ticket := range data {
+		record := []string{
+			strconv.Itoa(ticket.id),
+			ticket.name,
+			ticket.email,
+			ticket.destination,
+			formatDepartureTime(ticket.departureTime),
+			strconv.Itoa(ticket.ticketPrice),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ticketGob is the binary representation of a Ticket.
+type ticketGob struct {
+	ID            int
+	Name          string
+	Email         string
+	Destination   string
+	DepartureTime time.Time
+	TicketPrice   int
+}
+
+/*
+MarshalBinary encodes the specified tickets in a compact binary format (encoding/gob), so
+a parsed dataset can be cached and restored with UnmarshalBinary instead of parsing the
+CSV file again.
+*/
+func MarshalBinary(data []Ticket) ([]byte, error) {
+	records := make([]ticketGob, len(data))
+	for i, ticket := range data {
+		records[i] = ticketGob{
+			ID:            ticket.id,
+			Name:          ticket.name,
+			Email:         ticket.email,
+			Destination:   ticket.destination,
+			DepartureTime: ticket.departureTime,
+			TicketPrice:   ticket.ticketPrice,
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(records); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the tickets encoded by MarshalBinary.
+func UnmarshalBinary(encoded []byte) ([]Ticket, error) {
+	var records []ticketGob
+	if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(&records); err != nil {
+		return nil, err
+	}
+
+	tickets := make([]Ticket, len(records))
+	for i, record := range records {
+		tickets[i] = Ticket{
+			id:            record.ID,
+			name:          record.Name,
+			email:         record.Email,
+			destination:   record.Destination,
+			departureTime: record.DepartureTime,
+			ticketPrice:   record.TicketPrice,
+		}
+	}
+	return tickets, nil
+}
@@ -0,0 +1,176 @@
+package tickets
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicketsJSON(t *testing.T) {
+	t.Run("Encode empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		output, err := TicketsJSON(ticketSlice, false)
+
+		assert.Nil(t, output)
+		assert.Error(t, err)
+	})
+
+	t.Run("Encode valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedOutput := `[{"id":1,"name":"Tait Mc Caughan","email":"tmc0@scribd.com",` +
+			`"destination":"Finland","departure_time":"17:11","ticket_price":785}]`
+
+		output, err := TicketsJSON(ticketSlice, false)
+
+		assert.Equal(t, expectedOutput, string(output))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Encode valid ticket slice with indentation", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		expectedKeys := []string{"id", "name", "email", "destination", "departure_time", "ticket_price"}
+
+		output, err := TicketsJSON(ticketSlice, true)
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), "\n  {\n    \"id\": 1,")
+
+		// The output must be an array with one object per ticket, holding every field key
+		var decoded []map[string]interface{}
+		assert.NoError(t, json.Unmarshal(output, &decoded))
+		assert.Len(t, decoded, len(ticketSlice))
+		for _, object := range decoded {
+			assert.Len(t, object, len(expectedKeys))
+			for _, key := range expectedKeys {
+				assert.Contains(t, object, key)
+			}
+		}
+	})
+}
+
+func TestExportToJSON(t *testing.T) {
+	t.Run("Export empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+		var buffer bytes.Buffer
+
+		err := ExportToJSON(ticketSlice, &buffer)
+
+		assert.Empty(t, buffer.String())
+		assert.Error(t, err)
+	})
+
+	t.Run("Export valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		var buffer bytes.Buffer
+
+		expectedOutput := `[{"id":1,"name":"Tait Mc Caughan","email":"tmc0@scribd.com",` +
+			`"destination":"Finland","departure_time":"17:11","ticket_price":785}]` + "\n"
+
+		err := ExportToJSON(ticketSlice, &buffer)
+
+		assert.Equal(t, expectedOutput, buffer.String())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Round trip of a valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		datedTicket := ticketSlice[0]
+		datedTicket.departureTime = time.Date(2024, time.March, 15, 17, 11, 0, 0, time.UTC)
+		ticketSlice = append(ticketSlice, datedTicket)
+		var buffer bytes.Buffer
+
+		err := ExportToJSON(ticketSlice, &buffer)
+		assert.NoError(t, err)
+
+		var decoded []Ticket
+		assert.NoError(t, json.Unmarshal(buffer.Bytes(), &decoded))
+
+		assert.Equal(t, ticketSlice, decoded)
+	})
+
+	t.Run("Decode invalid departure time", func(t *testing.T) {
+		var ticket Ticket
+
+		err := json.Unmarshal([]byte(`{"id":1,"departure_time":"soon"}`), &ticket)
+
+		assert.Equal(t, Ticket{}, ticket)
+		assert.Error(t, err)
+	})
+}
+
+func TestExportToCSV(t *testing.T) {
+	t.Run("Export empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+		var buffer bytes.Buffer
+
+		err := ExportToCSV(ticketSlice, &buffer)
+
+		assert.Empty(t, buffer.String())
+		assert.Error(t, err)
+	})
+
+	t.Run("Export valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		var buffer bytes.Buffer
+
+		expectedOutput := "id,name,email,destination,departure_time,ticket_price\n" +
+			"1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n"
+
+		err := ExportToCSV(ticketSlice, &buffer)
+
+		assert.Equal(t, expectedOutput, buffer.String())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Round trip of a valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		quotedTicket := ticketSlice[0]
+		quotedTicket.name = "Mc Caughan, \"Tait\""
+		ticketSlice = append(ticketSlice, quotedTicket)
+		var buffer bytes.Buffer
+
+		err := ExportToCSV(ticketSlice, &buffer)
+		assert.NoError(t, err)
+
+		reextracted, err := ExtractTicketDataFromReader(strings.NewReader(buffer.String()))
+		assert.NoError(t, err)
+
+		assert.Equal(t, ticketSlice, reextracted)
+	})
+}
+
+func TestMarshalBinary(t *testing.T) {
+	t.Run("Round trip of a valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		encoded, err := MarshalBinary(ticketSlice)
+		assert.NoError(t, err)
+
+		decoded, err := UnmarshalBinary(encoded)
+		assert.NoError(t, err)
+
+		assert.Len(t, decoded, len(ticketSlice))
+		for i := range ticketSlice {
+			assert.True(t, ticketSlice[i].Equal(decoded[i]))
+		}
+	})
+
+	t.Run("Decode invalid data", func(t *testing.T) {
+		decoded, err := UnmarshalBinary([]byte("not a gob stream"))
+
+		assert.Nil(t, decoded)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,161 @@
+package tickets
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// dataChunk is a part of the CSV data holding whole records, together with the line where it starts.
+type dataChunk struct {
+	data      []byte
+	firstLine int
+}
+
+// chunkResult holds the tickets parsed from a chunk, the line of each one and the error that stopped it.
+type chunkResult struct {
+	tickets []Ticket
+	lines   []int
+	err     error
+}
+
+/*
+ExtractTicketDataParallel works like ExtractTicketData, but the file is split into one
+chunk of lines per worker and the chunks are read and parsed by the workers at the same
+time, which speeds up the extraction of very large files on machines with several cores.
+The returned tickets keep the order of the rows of the file.
+
+The chunks are only split at line breaks outside quoted fields, so a quoted field may still
+span several lines. The header row, gzip files and the errors are handled as in
+ExtractTicketData: if several rows are invalid, the error of the first one is returned. The
+whole file is loaded in memory before being split. It returns an error if workers is not
+positive.
+*/
+func ExtractTicketDataParallel(filename string, workers int) ([]Ticket, error) {
+	// If there are no workers, return an error
+	if workers < 1 {
+		return nil, fmt.Errorf("workers must be positive, got %d", workers)
+	}
+
+	// Open the CSV file
+	file, r, err := openTicketFile(osFS{}, filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return extractTicketsParallel(data, ExtractOptions{}, workers)
+}
+
+/*
+extractTicketsParallel is a utility function that parses all the tickets of the specified
+CSV data with the specified number of workers. The records are read and parsed by the same
+recordReader used by streamTickets, so the result is the same as the one of extractTickets.
+*/
+func extractTicketsParallel(data []byte, opts ExtractOptions, workers int) ([]Ticket, error) {
+	// Read the first record to map the columns and find the line where the tickets start
+	header, err := newRecordReader(bytes.NewReader(data), opts)
+	if err != nil {
+		return nil, err
+	}
+	_, firstLine, err := header.next()
+
+	// A file holding only a header has no tickets
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Skip the lines before the first ticket, which hold the header row if there is one
+	start := 0
+	for line := 1; line < firstLine; line++ {
+		start += bytes.IndexByte(data[start:], '\n') + 1
+	}
+
+	// Read and parse one chunk per worker, stopping each chunk at its first error
+	chunks := splitChunks(data[start:], workers, firstLine)
+	results := make([]chunkResult, len(chunks))
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(result *chunkResult, chunk dataChunk) {
+			defer wg.Done()
+			records := header.chunkReader(bytes.NewReader(chunk.data), chunk.firstLine)
+			for {
+				fields, lineNumber, err := records.next()
+				if err == io.EOF {
+					return
+				}
+				if err != nil {
+					result.err = err
+					return
+				}
+				ticket, err := records.parseRecord(fields, lineNumber)
+				if err != nil {
+					result.err = err
+					return
+				}
+				result.tickets = append(result.tickets, ticket)
+				result.lines = append(result.lines, lineNumber)
+			}
+		}(&results[i], chunk)
+	}
+	wg.Wait()
+
+	// Join the chunks in order, returning the first error and checking the repeated ids
+	var tickets []Ticket
+	seenIDs := map[int]int{}
+	for _, result := range results {
+		if opts.StrictUniqueIDs && header.parseColumn["id"] {
+			for i, ticket := range result.tickets {
+				if err := checkUniqueID(seenIDs, ticket.id, result.lines[i]); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if result.err != nil {
+			return nil, result.err
+		}
+		tickets = append(tickets, result.tickets...)
+	}
+	return tickets, nil
+}
+
+/*
+splitChunks is a utility function that splits the specified CSV data, which starts at the
+specified line, into at most the specified number of chunks of similar size. The data is
+only split at line breaks outside quoted fields, so each record lies within a single chunk.
+*/
+func splitChunks(data []byte, chunks int, firstLine int) []dataChunk {
+	chunkSize := (len(data) + chunks - 1) / chunks
+
+	var result []dataChunk
+	start, startLine, line := 0, firstLine, firstLine
+	inQuotes := false
+	for i, c := range data {
+		switch c {
+		case '"':
+			// An escaped quote ("") toggles twice, so it does not change the state
+			inQuotes = !inQuotes
+		case '\n':
+			line++
+			if !inQuotes && i+1-start >= chunkSize {
+				result = append(result, dataChunk{data: data[start : i+1], firstLine: startLine})
+				start, startLine = i+1, line
+			}
+		}
+	}
+
+	// The last chunk may not end with a line break
+	if start < len(data) {
+		result = append(result, dataChunk{data: data[start:], firstLine: startLine})
+	}
+	return result
+}
@@ -0,0 +1,202 @@
+package tickets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+generateTicketCSV is a utility function that returns a CSV file with the specified number
+of valid rows, cycling through a few destinations, departure times and prices.
+*/
+func generateTicketCSV(rows int) []byte {
+	destinations := []string{"Finland", "China", "Mongolia", "Peru", "Brazil"}
+
+	var builder strings.Builder
+	for i := 1; i <= rows; i++ {
+		fmt.Fprintf(
+			&builder,
+			"%d,Passenger %d,passenger%d@example.com,%s,%d:%02d,%d\n",
+			i,
+			i,
+			i,
+			destinations[i%len(destinations)],
+			i%24,
+			i%60,
+			100+i%1000,
+		)
+	}
+	return []byte(builder.String())
+}
+
+func TestExtractTicketDataParallel(t *testing.T) {
+	t.Run("Extract with a non-positive number of workers", func(t *testing.T) {
+		tickets, err := ExtractTicketDataParallel("./ticket_test_2.csv", 0)
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, "workers must be positive, got 0")
+	})
+
+	t.Run("Extract an empty file", func(t *testing.T) {
+		tickets, err := ExtractTicketDataParallel("./empty_ticket_test.csv", 4)
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, "empty CSV file")
+	})
+
+	t.Run("Output matches the serial extraction", func(t *testing.T) {
+		filename := writeTestFile(t, string(generateTicketCSV(1000)))
+		expectedData, _ := ExtractTicketData(filename)
+
+		for _, workers := range []int{1, 3, 8, 2000} {
+			data, err := ExtractTicketDataParallel(filename, workers)
+
+			assert.Equal(t, expectedData, data)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("Output matches the serial extraction with a header row", func(t *testing.T) {
+		filename := writeTestFile(t, "ticket_price,destination,departure_time,id,name,email\n"+
+			"785,Finland,17:11,1,Tait Mc Caughan,tmc0@scribd.com\n"+
+			"537,China,20:19,2,Padget McKee,pmckee1@hexun.com\n")
+		expectedData, _ := ExtractTicketData(filename)
+
+		data, err := ExtractTicketDataParallel(filename, 2)
+
+		assert.Equal(t, expectedData, data)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Extract gzip-compressed file", func(t *testing.T) {
+		filename := writeGzipTestFile(t, "./ticket_test_2.csv")
+		expectedData, _ := ExtractTicketData("./ticket_test_2.csv")
+
+		data, err := ExtractTicketDataParallel(filename, 2)
+
+		assert.Equal(t, expectedData, data)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Report the first invalid row across chunks", func(t *testing.T) {
+		rows := strings.Split(string(generateTicketCSV(100)), "\n")
+		rows[69] = "70,Passenger 70,passenger70@example.com,Peru,10:10"
+		rows[19] = "20,Passenger 20,passenger20@example.com,Peru,10:10,free"
+		content := strings.Join(rows, "\n")
+		filename := filepath.Join(t.TempDir(), "tickets.csv")
+		assert.NoError(t, os.WriteFile(filename, []byte(content), 0o644))
+		_, expectedErr := ExtractTicketData(filename)
+
+		data, err := ExtractTicketDataParallel(filename, 4)
+
+		assert.Nil(t, data)
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestExtractTicketsParallel(t *testing.T) {
+	t.Run("Output matches the serial extraction with options", func(t *testing.T) {
+		content := "1;Tait Mc Caughan;tmc0@scribd.com;Finland;17:11;785\n" +
+			"2;Padget McKee;pmckee1@hexun.com;China;20:19;537\n" +
+			"1;Yalonda Jermyn;yjermyn2@omniture.com;China;22:11;579\n"
+
+		for _, opts := range []ExtractOptions{
+			{Delimiter: ';'},
+			{Delimiter: ';', Columns: []string{"destination", "ticket_price"}},
+			{Delimiter: ';', ValidatePrices: true, MaxPrice: 600},
+			{Delimiter: ';', StrictUniqueIDs: true},
+		} {
+			expectedData, expectedErr := extractTickets(strings.NewReader(content), opts)
+
+			data, err := extractTicketsParallel([]byte(content), opts, 2)
+
+			assert.Equal(t, expectedData, data)
+			assert.Equal(t, expectedErr, err)
+		}
+	})
+
+	t.Run("Quoted line breaks are not split between chunks", func(t *testing.T) {
+		var builder strings.Builder
+		builder.WriteString("\nid,name,email,destination,departure_time,ticket_price\n")
+		for i := 1; i <= 50; i++ {
+			fmt.Fprintf(&builder, "%d,\"Passenger\n\"\"%d\"\"\",p%d@example.com,Peru,10:10,%d\n", i, i, i, i)
+		}
+		content := builder.String()
+		expectedData, _ := extractTickets(strings.NewReader(content), ExtractOptions{})
+
+		for _, workers := range []int{2, 7, 100} {
+			data, err := extractTicketsParallel([]byte(content), ExtractOptions{}, workers)
+
+			assert.Equal(t, expectedData, data, workers)
+			assert.NoError(t, err, workers)
+		}
+	})
+
+	t.Run("Malformed record in a later chunk", func(t *testing.T) {
+		rows := strings.Split(string(generateTicketCSV(100)), "\n")
+		rows[79] = "80,Bad \"quote,bad@example.com,Peru,10:00,100"
+		content := strings.Join(rows, "\n")
+		_, expectedErr := extractTickets(strings.NewReader(content), ExtractOptions{})
+
+		data, err := extractTicketsParallel([]byte(content), ExtractOptions{}, 4)
+
+		assert.Nil(t, data)
+		assert.Equal(t, expectedErr, err)
+		assert.ErrorContains(t, err, "line 80")
+	})
+}
+
+func TestSplitChunks(t *testing.T) {
+	t.Run("Split at line breaks outside quotes", func(t *testing.T) {
+		content := "1,a\n2,\"b\nc\"\n3,d\n4,e"
+
+		expectedChunks := []dataChunk{
+			{data: []byte("1,a\n2,\"b\nc\"\n"), firstLine: 5},
+			{data: []byte("3,d\n4,e"), firstLine: 8},
+		}
+
+		chunks := splitChunks([]byte(content), 2, 5)
+
+		assert.Equal(t, expectedChunks, chunks)
+	})
+
+	t.Run("Split empty data", func(t *testing.T) {
+		chunks := splitChunks(nil, 4, 1)
+
+		assert.Empty(t, chunks)
+	})
+}
+
+/*
+BenchmarkExtractTicketDataParallel compares the serial extraction with the parallel one
+using as many workers as the CPUs available, which is only faster on machines with several
+cores (run it with -cpu to try different numbers).
+*/
+func BenchmarkExtractTicketDataParallel(b *testing.B) {
+	filename := writeBenchmarkFile(b, benchmarkRows)
+
+	b.Run("serial", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := ExtractTicketData(filename); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		workers := runtime.GOMAXPROCS(0)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := ExtractTicketDataParallel(filename, workers); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
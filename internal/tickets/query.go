@@ -0,0 +1,111 @@
+package tickets
+
+import (
+	"sort"
+	"time"
+)
+
+/*
+Query composes filters and sorting over a set of tickets with chainable methods, e.g.
+
+	results, err := NewQuery(data).
+		WhereDestination("China").
+		WherePriceBetween(500, 1000).
+		SortBy(ByDepartureTime, true).
+		Results()
+
+The methods only record each step and return the same Query, so the evaluation is deferred
+until Results is called. The steps are applied in the order they were added.
+*/
+type Query struct {
+	data  []Ticket
+	steps []func([]Ticket) ([]Ticket, error)
+}
+
+// NewQuery returns a query over the specified tickets, which are not modified by the query.
+func NewQuery(data []Ticket) *Query {
+	return &Query{data: data}
+}
+
+// WhereDestination keeps the tickets with the specified destination.
+func (q *Query) WhereDestination(destination string) *Query {
+	q.steps = append(q.steps, func(data []Ticket) ([]Ticket, error) {
+		filtered := []Ticket{}
+		for _, ticket := range data {
+			if ticket.destination == destination {
+				filtered = append(filtered, ticket)
+			}
+		}
+		return filtered, nil
+	})
+	return q
+}
+
+/*
+WherePriceBetween keeps the tickets whose price is between minPrice and maxPrice (both
+inclusive), as in FilterByPriceRange.
+*/
+func (q *Query) WherePriceBetween(minPrice, maxPrice int) *Query {
+	q.steps = append(q.steps, func(data []Ticket) ([]Ticket, error) {
+		return FilterByPriceRange(data, minPrice, maxPrice)
+	})
+	return q
+}
+
+/*
+WhereTimeBetween keeps the tickets whose departure time of day is between start and end
+(both inclusive), as in GetTicketsInTimeWindow.
+*/
+func (q *Query) WhereTimeBetween(start, end time.Time) *Query {
+	q.steps = append(q.steps, func(data []Ticket) ([]Ticket, error) {
+		return GetTicketsInTimeWindow(data, start, end)
+	})
+	return q
+}
+
+/*
+SortBy sorts the tickets by the specified key, in ascending order if ascending is true and
+in descending order otherwise. The sort is stable, so chaining several SortBy calls sorts
+by the last key first and uses the previous ones to break ties.
+*/
+func (q *Query) SortBy(key SortKey, ascending bool) *Query {
+	q.steps = append(q.steps, func(data []Ticket) ([]Ticket, error) {
+		sort.SliceStable(data, func(i, j int) bool {
+			comparison := compareByKey(data[i], data[j], key)
+			if ascending {
+				return comparison < 0
+			}
+			return comparison > 0
+		})
+		return data, nil
+	})
+	return q
+}
+
+/*
+Results applies the steps of the query and returns the resulting tickets. If no ticket
+matches the filters, it returns an empty slice.
+
+It returns an error if the tickets of the query are empty or if a step has invalid
+arguments, such as an inverted price range or time window.
+*/
+func (q *Query) Results() ([]Ticket, error) {
+	// If the slice is empty, return an error
+	if len(q.data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// Copy the tickets so the steps can sort them in place
+	results := make([]Ticket, len(q.data))
+	copy(results, q.data)
+
+	// Apply each step to the results of the previous one
+	var err error
+	for _, step := range q.steps {
+		results, err = step(results)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,62 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	t.Run("Query empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		results, err := NewQuery(ticketSlice).WhereDestination("China").Results()
+
+		assert.Nil(t, results)
+		assert.ErrorIs(t, err, ErrEmptyData)
+	})
+
+	t.Run("Compose three filters and a sort", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		originalSlice := append([]Ticket{}, ticketSlice...)
+		start, _ := time.Parse("15:04", "8:00")
+		end, _ := time.Parse("15:04", "21:00")
+
+		// Finland 17:11 785, China 20:19 537, China 8:30 600 and Finland 13:45 400
+		// depart within the window; only the China ones are kept, most expensive first
+		expectedData := []Ticket{ticketSlice[2], ticketSlice[1]}
+
+		results, err := NewQuery(ticketSlice).
+			WhereTimeBetween(start, end).
+			WherePriceBetween(500, 800).
+			WhereDestination("China").
+			SortBy(ByPrice, false).
+			Results()
+
+		assert.Equal(t, expectedData, results)
+		assert.Equal(t, originalSlice, ticketSlice)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Query without matches", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		results, err := NewQuery(ticketSlice).WhereDestination("The Moon").Results()
+
+		assert.Empty(t, results)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Query with an inverted price range", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		results, err := NewQuery(ticketSlice).WherePriceBetween(800, 500).Results()
+
+		assert.Nil(t, results)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,54 @@
+package tickets
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// reportTopDestinations is the number of destinations listed by WriteReport.
+const reportTopDestinations = 3
+
+/*
+WriteReport writes a plain-text report of the specified tickets to w, meant to be printed
+or emailed. The report holds the number of tickets, the total revenue, the average price,
+the number of tickets of each period of the day and the three destinations with the most
+tickets, with the values of each section aligned in a column.
+
+If the data is empty, it returns an error and nothing is written.
+*/
+func WriteReport(data []Ticket, w io.Writer) error {
+	// Obtain the statistics of the report
+	summary, err := Summarize(data)
+
+	// If the slice is empty, return an error
+	if err != nil {
+		return err
+	}
+	countByPeriod, err := GetCountByPeriodTyped(data)
+	if err != nil {
+		return err
+	}
+	topDestinations, err := GetTopNDestinations(data, reportTopDestinations)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Total tickets:\t%d\n", summary.TotalTickets)
+	fmt.Fprintf(tw, "Total revenue:\t%d\n", summary.TotalRevenue)
+	fmt.Fprintf(tw, "Average price:\t%.2f\n", summary.AveragePrice)
+
+	// Write the tickets of each period in chronological order
+	fmt.Fprintf(tw, "\nTickets by period:\n")
+	for _, period := range periods {
+		fmt.Fprintf(tw, "  %s\t%d\n", period, countByPeriod[period])
+	}
+
+	// Write the most popular destinations
+	fmt.Fprintf(tw, "\nTop destinations:\n")
+	for i, destination := range topDestinations {
+		fmt.Fprintf(tw, "  %d. %s\t%d\n", i+1, destination.Destination, destination.Count)
+	}
+	return tw.Flush()
+}
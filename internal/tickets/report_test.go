@@ -0,0 +1,46 @@
+package tickets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteReport(t *testing.T) {
+	t.Run("Write report of empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+		var buffer bytes.Buffer
+
+		err := WriteReport(ticketSlice, &buffer)
+
+		assert.Empty(t, buffer.String())
+		assert.Error(t, err)
+	})
+
+	t.Run("Write report of valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		var buffer bytes.Buffer
+
+		expectedReport := "Total tickets:  5\n" +
+			"Total revenue:  3560\n" +
+			"Average price:  712.00\n" +
+			"\n" +
+			"Tickets by period:\n" +
+			"  early_morning  1\n" +
+			"  morning        1\n" +
+			"  afternoon      2\n" +
+			"  evening        1\n" +
+			"\n" +
+			"Top destinations:\n" +
+			"  1. China     2\n" +
+			"  2. Finland   2\n" +
+			"  3. Mongolia  1\n"
+
+		err := WriteReport(ticketSlice, &buffer)
+
+		assert.Equal(t, expectedReport, buffer.String())
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,115 @@
+package tickets
+
+import (
+	"sort"
+	"strings"
+)
+
+/*
+SortByPrice returns a copy of the specified tickets sorted by price, in ascending order if
+ascending is true and in descending order otherwise. Tickets with the same price keep
+their original relative order, and the specified slice is not modified.
+*/
+func SortByPrice(data []Ticket, ascending bool) []Ticket {
+	// Copy the tickets so the caller's slice keeps its order
+	sorted := make([]Ticket, len(data))
+	copy(sorted, data)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if ascending {
+			return sorted[i].ticketPrice < sorted[j].ticketPrice
+		}
+		return sorted[i].ticketPrice > sorted[j].ticketPrice
+	})
+	return sorted
+}
+
+/*
+SortByDepartureTime returns a copy of the specified tickets sorted by departure time, in
+ascending (chronological) order if ascending is true and in descending order otherwise.
+Tickets with the same departure time keep their original relative order, and the
+specified slice is not modified.
+*/
+func SortByDepartureTime(data []Ticket, ascending bool) []Ticket {
+	// Copy the tickets so the caller's slice keeps its order
+	sorted := make([]Ticket, len(data))
+	copy(sorted, data)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if ascending {
+			return sorted[i].departureTime.Before(sorted[j].departureTime)
+		}
+		return sorted[i].departureTime.After(sorted[j].departureTime)
+	})
+	return sorted
+}
+
+// SortKey is a field of the tickets that SortTickets can sort by.
+type SortKey int
+
+// Fields that can be used to sort the tickets, always in ascending order.
+const (
+	ByID SortKey = iota
+	ByDestination
+	ByDepartureTime
+	ByPrice
+)
+
+/*
+compareByKey is a utility function that compares two tickets by the specified key. It
+returns -1 if a goes before b, 1 if a goes after b and 0 if they are equal for that key.
+*/
+func compareByKey(a, b Ticket, key SortKey) int {
+	switch key {
+	case ByID:
+		return compareInts(a.id, b.id)
+	case ByDestination:
+		return strings.Compare(a.destination, b.destination)
+	case ByDepartureTime:
+		switch {
+		case a.departureTime.Before(b.departureTime):
+			return -1
+		case a.departureTime.After(b.departureTime):
+			return 1
+		}
+	case ByPrice:
+		return compareInts(a.ticketPrice, b.ticketPrice)
+	}
+	return 0
+}
+
+/*
+compareInts is a utility function that returns -1 if a is less than b, 1 if a is greater
+than b and 0 if they are equal. Unlike a - b, it cannot overflow.
+*/
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+/*
+SortTickets returns a copy of the specified tickets sorted in ascending order by the
+specified keys: tickets are sorted by the first key, tickets equal for the first key are
+sorted by the second one, and so on. Tickets equal for every key keep their original
+relative order, and the specified slice is not modified.
+*/
+func SortTickets(data []Ticket, keys ...SortKey) []Ticket {
+	// Copy the tickets so the caller's slice keeps its order
+	sorted := make([]Ticket, len(data))
+	copy(sorted, data)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		for _, key := range keys {
+			if comparison := compareByKey(sorted[i], sorted[j], key); comparison != 0 {
+				return comparison < 0
+			}
+		}
+		return false
+	})
+	return sorted
+}
@@ -0,0 +1,149 @@
+package tickets
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortByPrice(t *testing.T) {
+	t.Run("Sort empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		sorted := SortByPrice(ticketSlice, true)
+
+		assert.Empty(t, sorted)
+	})
+
+	t.Run("Sort in ascending order", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		originalSlice := append([]Ticket{}, ticketSlice...)
+
+		// Prices of the test file: 785, 537, 579 and 1238
+		expectedData := []Ticket{ticketSlice[1], ticketSlice[2], ticketSlice[0], ticketSlice[3]}
+
+		sorted := SortByPrice(ticketSlice, true)
+
+		assert.Equal(t, expectedData, sorted)
+		assert.Equal(t, originalSlice, ticketSlice)
+	})
+
+	t.Run("Sort in descending order", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		originalSlice := append([]Ticket{}, ticketSlice...)
+		expectedData := []Ticket{ticketSlice[3], ticketSlice[0], ticketSlice[2], ticketSlice[1]}
+
+		sorted := SortByPrice(ticketSlice, false)
+
+		assert.Equal(t, expectedData, sorted)
+		assert.Equal(t, originalSlice, ticketSlice)
+	})
+}
+
+func TestSortByDepartureTime(t *testing.T) {
+	t.Run("Sort in ascending order", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		originalSlice := append([]Ticket{}, ticketSlice...)
+
+		// Departure times of the test file: 10:11, 16:19, 22:11 and 3:16
+		expectedData := []Ticket{ticketSlice[3], ticketSlice[0], ticketSlice[1], ticketSlice[2]}
+
+		sorted := SortByDepartureTime(ticketSlice, true)
+
+		assert.Equal(t, expectedData, sorted)
+		assert.Equal(t, originalSlice, ticketSlice)
+	})
+
+	t.Run("Sort in descending order", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		originalSlice := append([]Ticket{}, ticketSlice...)
+		expectedData := []Ticket{ticketSlice[2], ticketSlice[1], ticketSlice[0], ticketSlice[3]}
+
+		sorted := SortByDepartureTime(ticketSlice, false)
+
+		assert.Equal(t, expectedData, sorted)
+		assert.Equal(t, originalSlice, ticketSlice)
+	})
+}
+
+func TestSortTickets(t *testing.T) {
+	t.Run("Sort by destination, departure time and price", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		originalSlice := append([]Ticket{}, ticketSlice...)
+
+		// Two China tickets depart at the same time, so their price decides the order
+		sameTime := ticketSlice[2]
+		sameTime.id = 6
+		sameTime.ticketPrice = 100
+		ticketSlice = append(ticketSlice, sameTime)
+
+		expectedData := []Ticket{
+			ticketSlice[5], // China, 8:30, 100
+			ticketSlice[2], // China, 8:30, 600
+			ticketSlice[1], // China, 20:19
+			ticketSlice[4], // Finland, 13:45
+			ticketSlice[0], // Finland, 17:11
+			ticketSlice[3], // Mongolia
+		}
+
+		sorted := SortTickets(ticketSlice, ByDestination, ByDepartureTime, ByPrice)
+
+		assert.Equal(t, expectedData, sorted)
+		assert.Equal(t, originalSlice, ticketSlice[:5])
+	})
+
+	t.Run("Sort by price and id", func(t *testing.T) {
+		ticketSlice := []Ticket{
+			{id: 3, ticketPrice: 200},
+			{id: 2, ticketPrice: 100},
+			{id: 1, ticketPrice: 200},
+		}
+
+		expectedData := []Ticket{ticketSlice[1], ticketSlice[2], ticketSlice[0]}
+
+		sorted := SortTickets(ticketSlice, ByPrice, ByID)
+
+		assert.Equal(t, expectedData, sorted)
+	})
+
+	t.Run("Sort without keys", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		sorted := SortTickets(ticketSlice)
+
+		assert.Equal(t, ticketSlice, sorted)
+	})
+}
+
+func TestCompareByKey(t *testing.T) {
+	t.Run("Compare extreme values without overflow", func(t *testing.T) {
+		low := Ticket{id: math.MinInt, ticketPrice: math.MinInt}
+		high := Ticket{id: math.MaxInt, ticketPrice: math.MaxInt}
+		one := Ticket{id: 1, ticketPrice: 1}
+
+		for _, key := range []SortKey{ByID, ByPrice} {
+			assert.Equal(t, -1, compareByKey(low, one, key), key)
+			assert.Equal(t, 1, compareByKey(one, low, key), key)
+			assert.Equal(t, -1, compareByKey(low, high, key), key)
+			assert.Equal(t, 1, compareByKey(high, low, key), key)
+			assert.Equal(t, 0, compareByKey(high, high, key), key)
+		}
+	})
+
+	t.Run("Sort extreme ids", func(t *testing.T) {
+		ticketSlice := []Ticket{{id: 1}, {id: math.MinInt}, {id: math.MaxInt}}
+
+		expectedData := []Ticket{{id: math.MinInt}, {id: 1}, {id: math.MaxInt}}
+
+		sorted := SortTickets(ticketSlice, ByID)
+
+		assert.Equal(t, expectedData, sorted)
+	})
+}
@@ -0,0 +1,125 @@
+package tickets
+
+import (
+	"fmt"
+)
+
+/*
+TicketStore holds a set of tickets together with indexes that speed up repeated queries.
+The indexes are built lazily, the first time a method that needs them is called, and are
+reused by the following calls. Each method returns the same results as its equivalent
+free function of this package.
+
+A TicketStore must be created with NewTicketStore or LoadTicketStore. It is not safe for
+concurrent use.
+*/
+type TicketStore struct {
+	tickets       []Ticket
+	byID          map[int]Ticket
+	byDestination map[string][]Ticket
+}
+
+/*
+NewTicketStore returns a store holding the specified tickets. The slice is copied, so
+modifying it afterwards does not affect the store.
+*/
+func NewTicketStore(data []Ticket) *TicketStore {
+	tickets := make([]Ticket, len(data))
+	copy(tickets, data)
+	return &TicketStore{tickets: tickets}
+}
+
+// LoadTicketStore returns a store holding the tickets extracted from the specified CSV file.
+func LoadTicketStore(filename string) (*TicketStore, error) {
+	tickets, err := ExtractTicketData(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &TicketStore{tickets: tickets}, nil
+}
+
+// Len returns the number of tickets of the store.
+func (s *TicketStore) Len() int {
+	return len(s.tickets)
+}
+
+/*
+Tickets returns the tickets of the store in their original order. The returned slice is
+a copy, so modifying it does not affect the store.
+*/
+func (s *TicketStore) Tickets() []Ticket {
+	tickets := make([]Ticket, len(s.tickets))
+	copy(tickets, s.tickets)
+	return tickets
+}
+
+/*
+GetByID works like GetTicketByID, but the lookup uses an index by id. If several tickets
+share the id, the first one is returned.
+*/
+func (s *TicketStore) GetByID(id int) (Ticket, error) {
+	// If the store is empty, return an error
+	if len(s.tickets) == 0 {
+		return Ticket{}, ErrEmptyData
+	}
+
+	// Build the index on first use
+	if s.byID == nil {
+		s.byID = BuildIDIndex(s.tickets)
+	}
+
+	ticket, exists := s.byID[id]
+	if !exists {
+		return Ticket{}, fmt.Errorf("no ticket found with id %d", id)
+	}
+	return ticket, nil
+}
+
+/*
+FilterByDestination works like the FilterByDestination function, but the lookup uses an
+index by destination. The returned slice is a copy, so modifying it does not affect the
+store.
+*/
+func (s *TicketStore) FilterByDestination(destination string) ([]Ticket, error) {
+	// If the store is empty, return an error
+	if len(s.tickets) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// Build the index on first use
+	if s.byDestination == nil {
+		s.byDestination = map[string][]Ticket{}
+		for _, ticket := range s.tickets {
+			s.byDestination[ticket.destination] = append(s.byDestination[ticket.destination], ticket)
+		}
+	}
+
+	// Return a error if the destination is not found
+	indexed, exists := s.byDestination[destination]
+	if !exists {
+		return nil, fmt.Errorf("%w %s", ErrDestinationNotFound, destination)
+	}
+
+	tickets := make([]Ticket, len(indexed))
+	copy(tickets, indexed)
+	return tickets, nil
+}
+
+// TotalByDestination works like GetTotalTicketsByDestination, using the index by destination.
+func (s *TicketStore) TotalByDestination(destination string) (int, error) {
+	tickets, err := s.FilterByDestination(destination)
+	if err != nil {
+		return 0, err
+	}
+	return len(tickets), nil
+}
+
+// CountByPeriod works like GetCountByPeriod over the tickets of the store.
+func (s *TicketStore) CountByPeriod() (map[string]int, error) {
+	return GetCountByPeriod(s.tickets)
+}
+
+// AveragePrice works like GetAveragePrice over the tickets of the store.
+func (s *TicketStore) AveragePrice() (float64, error) {
+	return GetAveragePrice(s.tickets)
+}
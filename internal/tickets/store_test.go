@@ -0,0 +1,86 @@
+package tickets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicketStore(t *testing.T) {
+	t.Run("Empty store", func(t *testing.T) {
+		store := NewTicketStore(nil)
+
+		_, errByID := store.GetByID(1)
+		_, errByDestination := store.TotalByDestination("China")
+		_, errByPeriod := store.CountByPeriod()
+		_, errAverage := store.AveragePrice()
+
+		assert.Equal(t, 0, store.Len())
+		assert.Error(t, errByID)
+		assert.Error(t, errByDestination)
+		assert.Error(t, errByPeriod)
+		assert.Error(t, errAverage)
+	})
+
+	t.Run("Load store from a missing file", func(t *testing.T) {
+		store, err := LoadTicketStore("./missing_ticket_test.csv")
+
+		assert.Nil(t, store)
+		assert.Error(t, err)
+	})
+
+	t.Run("Store results match the free functions", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		store, err := LoadTicketStore(filename)
+		assert.NoError(t, err)
+		assert.Equal(t, ticketSlice, store.Tickets())
+
+		// Repeat the lookups so the cached indexes are used
+		for i := 0; i < 2; i++ {
+			for _, destination := range []string{"Finland", "China", "Mongolia", "Peru"} {
+				expectedTotal, expectedErr := GetTotalTicketsByDestination(ticketSlice, destination)
+				total, err := store.TotalByDestination(destination)
+				assert.Equal(t, expectedTotal, total)
+				assert.Equal(t, expectedErr, err)
+
+				expectedTickets, _ := FilterByDestination(ticketSlice, destination)
+				tickets, _ := store.FilterByDestination(destination)
+				assert.Equal(t, expectedTickets, tickets)
+			}
+
+			for _, id := range []int{1, 3, 5, 42} {
+				expectedTicket, expectedErr := GetTicketByID(ticketSlice, id)
+				ticket, err := store.GetByID(id)
+				assert.Equal(t, expectedTicket, ticket)
+				assert.Equal(t, expectedErr, err)
+			}
+		}
+
+		expectedCounts, _ := GetCountByPeriod(ticketSlice)
+		counts, err := store.CountByPeriod()
+		assert.Equal(t, expectedCounts, counts)
+		assert.NoError(t, err)
+
+		expectedAverage, _ := GetAveragePrice(ticketSlice)
+		average, err := store.AveragePrice()
+		assert.Equal(t, expectedAverage, average)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Store is not affected by changes to slices", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		store := NewTicketStore(ticketSlice)
+
+		ticketSlice[0].destination = "Peru"
+		store.Tickets()[1].destination = "Peru"
+		filtered, _ := store.FilterByDestination("China")
+		filtered[0].destination = "Peru"
+
+		_, err := store.TotalByDestination("Peru")
+		total, _ := store.TotalByDestination("China")
+		assert.Error(t, err)
+		assert.Equal(t, 2, total)
+	})
+}
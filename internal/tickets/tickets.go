@@ -1,13 +1,38 @@
 package tickets
 
 import (
+	"compress/gzip"
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"net/mail"
 	"os"
+	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// Errors returned by the functions of this package, which can be checked with errors.Is.
+var (
+	// ErrEmptyData is returned when a function receives no tickets.
+	ErrEmptyData = errors.New("no tickets found")
+
+	// ErrEmptyFile is returned when a CSV file has no records.
+	ErrEmptyFile = errors.New("empty CSV file")
+
+	// ErrDestinationNotFound is returned (wrapped with the destination) when no ticket
+	// goes to the requested destination.
+	ErrDestinationNotFound = errors.New("no tickets found for destination")
+
+	// ErrInvalidTimeRange is returned when the start of a time range is after its end.
+	ErrInvalidTimeRange = errors.New("start time must be before end time")
+)
+
 // Ticket is a struct that represents a single ticket.
 type Ticket struct {
 	id            int
@@ -19,209 +44,2731 @@ type Ticket struct {
 }
 
 /*
-ExtractTicketData extracts tickets information from a CSV file.
-It takes a CSV filename and returns a slice of Ticket structs.
+NewTicket creates a ticket with the specified data, which is the only way to build a
+ticket outside this package. It returns an error if the id is not positive, the name is
+empty, the email is not a valid address or the price is negative.
+*/
+func NewTicket(id int, name, email, destination string, departure time.Time, price int) (Ticket, error) {
+	// Validate the ticket data
+	if id <= 0 {
+		return Ticket{}, fmt.Errorf("id must be positive, got %d", id)
+	}
+	if strings.TrimSpace(name) == "" {
+		return Ticket{}, errors.New("name must not be empty")
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return Ticket{}, fmt.Errorf("invalid email %q", email)
+	}
+	if price < 0 {
+		return Ticket{}, fmt.Errorf("price must not be negative, got %d", price)
+	}
+
+	return Ticket{
+		id:            id,
+		name:          name,
+		email:         email,
+		destination:   destination,
+		departureTime: departure,
+		ticketPrice:   price,
+	}, nil
+}
+
+/*
+ValidateTickets checks the specified tickets for consistency and returns one error for each
+problem found, in the order of the tickets. The problems detected are repeated ids (reported
+once per id), empty names, invalid emails, negative prices and zero departure times. An
+empty result means the data is clean.
+*/
+func ValidateTickets(data []Ticket) []error {
+	var problems []error
+
+	// Number of tickets seen with each id
+	seenIDs := map[int]int{}
+
+	// Loop through each ticket
+	for _, ticket := range data {
+		seenIDs[ticket.id]++
+		if seenIDs[ticket.id] == 2 {
+			problems = append(problems, fmt.Errorf("duplicate ticket id: %d", ticket.id))
+		}
+		if strings.TrimSpace(ticket.name) == "" {
+			problems = append(problems, fmt.Errorf("ticket %d: empty name", ticket.id))
+		}
+		if _, err := mail.ParseAddress(ticket.email); err != nil {
+			problems = append(problems, fmt.Errorf("ticket %d: invalid email %q", ticket.id, ticket.email))
+		}
+		if ticket.ticketPrice < 0 {
+			problems = append(problems, fmt.Errorf("ticket %d: negative price %d", ticket.id, ticket.ticketPrice))
+		}
+		if ticket.departureTime.IsZero() {
+			problems = append(problems, fmt.Errorf("ticket %d: zero departure time", ticket.id))
+		}
+	}
+	return problems
+}
+
+// ID returns the id of the ticket.
+func (t Ticket) ID() int {
+	return t.id
+}
+
+// Name returns the name of the passenger of the ticket.
+func (t Ticket) Name() string {
+	return t.name
+}
+
+// Email returns the email of the passenger of the ticket.
+func (t Ticket) Email() string {
+	return t.email
+}
+
+// Destination returns the destination of the ticket.
+func (t Ticket) Destination() string {
+	return t.destination
+}
+
+// DepartureTime returns the departure time of the ticket.
+func (t Ticket) DepartureTime() time.Time {
+	return t.departureTime
+}
+
+// TicketPrice returns the price of the ticket.
+func (t Ticket) TicketPrice() int {
+	return t.ticketPrice
+}
+
+/*
+Equal reports whether two tickets hold the same data. The departure times are compared
+with time.Time.Equal, so the same instant in different locations is considered equal.
+*/
+func (t Ticket) Equal(other Ticket) bool {
+	return t.id == other.id &&
+		t.name == other.name &&
+		t.email == other.email &&
+		t.destination == other.destination &&
+		t.departureTime.Equal(other.departureTime) &&
+		t.ticketPrice == other.ticketPrice
+}
+
+/*
+String implements the fmt.Stringer interface, so printing a ticket shows a readable
+summary such as "Ticket#1 Tait Mc Caughan -> Finland at 17:11 ($785)".
+*/
+func (t Ticket) String() string {
+	return fmt.Sprintf(
+		"Ticket#%d %s -> %s at %s ($%d)",
+		t.id,
+		t.name,
+		t.destination,
+		formatDepartureTime(t.departureTime),
+		t.ticketPrice,
+	)
+}
+
+// ticketColumns are the names of the CSV columns, in the order they appear in the file.
+var ticketColumns = []string{"id", "name", "email", "destination", "departure_time", "ticket_price"}
+
+/*
+HeaderRow returns the names of the CSV columns in their canonical order, which is the
+header that should be used when writing tickets to a CSV file.
+*/
+func HeaderRow() []string {
+	header := make([]string, len(ticketColumns))
+	copy(header, ticketColumns)
+	return header
+}
+
+// ExtractOptions holds the options that change how ExtractTicketDataWithOptions parses a file.
+type ExtractOptions struct {
+	// StrictUniqueIDs makes the extraction fail as soon as a ticket id is repeated.
+	StrictUniqueIDs bool
+
+	// Columns restricts the parsing to the named columns (see ExtractTicketDataColumns).
+	// A nil slice parses every column.
+	Columns []string
+
+	// ValidateEmails makes the extraction fail if an email is not a valid address
+	// according to net/mail.ParseAddress. It is disabled by default because some
+	// datasets are dirty by design.
+	ValidateEmails bool
+
+	// Delimiter is the field separator of the file. The zero value means a comma.
+	Delimiter rune
+
+	// ValidatePrices makes the extraction fail if a ticket price is negative. A price of
+	// zero is allowed, since free tickets are valid.
+	ValidatePrices bool
+
+	// MaxPrice makes the extraction fail if a ticket price is greater than it, when
+	// ValidatePrices is enabled. A MaxPrice of 0 means there is no upper limit.
+	MaxPrice int
+
+	// Layout sets the position of each column in files without a header row. A nil
+	// layout means the documented order (see DefaultColumnLayout). If the file has a
+	// header row, the columns are mapped by name and the layout is ignored.
+	Layout *ColumnLayout
+}
+
+// ColumnLayout holds the position (starting at 0) of each ticket field in a CSV record.
+type ColumnLayout struct {
+	ID            int
+	Name          int
+	Email         int
+	Destination   int
+	DepartureTime int
+	TicketPrice   int
+}
+
+// DefaultColumnLayout returns the documented column order of the CSV files.
+func DefaultColumnLayout() ColumnLayout {
+	return ColumnLayout{ID: 0, Name: 1, Email: 2, Destination: 3, DepartureTime: 4, TicketPrice: 5}
+}
+
+/*
+columnIndex is a utility function that returns the position of each column of the layout,
+keyed by the column names. It returns an error if a position is negative or if two columns
+share the same position.
+*/
+func (l ColumnLayout) columnIndex() (map[string]int, error) {
+	positions := []int{l.ID, l.Name, l.Email, l.Destination, l.DepartureTime, l.TicketPrice}
+
+	columnIndex := map[string]int{}
+	columnAt := map[int]string{}
+	for i, column := range ticketColumns {
+		position := positions[i]
+		if position < 0 {
+			return nil, fmt.Errorf("column %q has a negative position %d", column, position)
+		}
+		if other, exists := columnAt[position]; exists {
+			return nil, fmt.Errorf("columns %q and %q share position %d", other, column, position)
+		}
+		columnAt[position] = column
+		columnIndex[column] = position
+	}
+	return columnIndex, nil
+}
+
+/*
+ExtractTicketData extracts tickets information from a CSV file.
+It takes a CSV filename and returns a slice of Ticket structs.
+
+The CSV file must be formatted as follows:
+id,name,email,destination,departure_time,ticket_price.
+
+The file may start with a header row holding those column names, in which case the
+columns are mapped by name and may appear in any order. If the filename ends in .gz, the
+file is decompressed with gzip before being parsed.
+
+The departure_time column may hold a time of day ("17:11"), a date and time
+("2024-03-15 17:11") or an RFC3339 timestamp ("2024-03-15T17:11:00Z"), and each row may
+use a different format.
+*/
+func ExtractTicketData(filename string) ([]Ticket, error) {
+	return ExtractTicketDataWithOptions(filename, ExtractOptions{})
+}
+
+/*
+ExtractTicketDataColumns works like ExtractTicketData, but it only parses and validates the
+specified columns, which speeds up the processing of large files when most columns are
+unused. The column names are the ones of the CSV format: id, name, email, destination,
+departure_time and ticket_price.
+
+The fields of the columns that are not requested are left with their zero value: 0 for
+the id and the price, an empty string for the name, email and destination and the zero
+time.Time for the departure time. It returns an error if a column name is unknown.
+*/
+func ExtractTicketDataColumns(filename string, columns []string) ([]Ticket, error) {
+	if columns == nil {
+		columns = []string{}
+	}
+	return ExtractTicketDataWithOptions(filename, ExtractOptions{Columns: columns})
+}
+
+/*
+ExtractTicketDataWithOptions works like ExtractTicketData, but the parsing can be
+customized with the specified options.
+
+If StrictUniqueIDs is enabled and a ticket id is repeated, it returns an error with the
+line numbers of both occurrences. The check is skipped if the id column is not parsed.
+
+If ValidateEmails is enabled and an email is empty or malformed, it returns an error with
+the line number and the offending value. Likewise, if ValidatePrices is enabled, it returns
+an error for a negative price or a price above MaxPrice.
+*/
+func ExtractTicketDataWithOptions(filename string, opts ExtractOptions) ([]Ticket, error) {
+	return extractTicketDataFS(osFS{}, filename, opts)
+}
+
+/*
+osFS is a file system that opens the names as they are with os.Open, so they can be any
+path of the operating system and the errors mention the path given by the caller, unlike
+the names of os.DirFS, which must be relative to a root directory.
+*/
+type osFS struct{}
+
+// Open implements the fs.FS interface.
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+/*
+ExtractTicketDataFS works like ExtractTicketData, but the file is read from the specified
+file system, so tickets can be parsed from embedded files (embed.FS) or any other fs.FS.
+The name must follow the fs.FS conventions: slash-separated and relative to the root.
+*/
+func ExtractTicketDataFS(fsys fs.FS, name string) ([]Ticket, error) {
+	return extractTicketDataFS(fsys, name, ExtractOptions{})
+}
+
+// extractTicketDataFS is a utility function that parses all the tickets of the specified file of fsys.
+func extractTicketDataFS(fsys fs.FS, name string, opts ExtractOptions) ([]Ticket, error) {
+	file, r, err := openTicketFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return extractTickets(r, opts)
+}
+
+/*
+openTicketFile is a utility function that opens the specified file of fsys and returns it
+together with a reader of its CSV data, which decompresses the file on the fly if its name
+ends in .gz. The file must be closed by the caller.
+
+If the file is empty, it returns ErrEmptyFile before anything is parsed.
+*/
+func openTicketFile(fsys fs.FS, name string) (fs.File, io.Reader, error) {
+	// Open the CSV file
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If the file is empty, return an error before parsing anything
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	if info.Size() == 0 {
+		file.Close()
+		return nil, nil, ErrEmptyFile
+	}
+
+	// Decompress the file on the fly if it is gzipped
+	if strings.EqualFold(path.Ext(name), ".gz") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		return file, gzipReader, nil
+	}
+	return file, file, nil
+}
+
+/*
+ExtractTicketDataWithDelimiter works like ExtractTicketData, but the fields of the file are
+separated by the specified delimiter instead of a comma (e.g. ';' or '\t'). It returns an
+error if the delimiter is not valid for a CSV file, such as a quote or a line break.
+*/
+func ExtractTicketDataWithDelimiter(filename string, delim rune) ([]Ticket, error) {
+	return ExtractTicketDataWithOptions(filename, ExtractOptions{Delimiter: delim})
+}
+
+/*
+ExtractTicketDataWithLayout works like ExtractTicketData, but the columns of the records
+are located with the specified layout instead of the documented order, for files written
+by systems that order the columns differently. The records must have exactly as many fields
+as needed to hold the last column of the layout.
+
+It returns an error if the layout has a negative position or two columns at the same
+position.
+*/
+func ExtractTicketDataWithLayout(filename string, layout ColumnLayout) ([]Ticket, error) {
+	return ExtractTicketDataWithOptions(filename, ExtractOptions{Layout: &layout})
+}
+
+/*
+ExtractTicketDataStrict works like ExtractTicketData, but it returns an error if two rows
+share the same ticket id, so corrupted files are rejected before they reach any join.
+*/
+func ExtractTicketDataStrict(filename string) ([]Ticket, error) {
+	return ExtractTicketDataWithOptions(filename, ExtractOptions{StrictUniqueIDs: true})
+}
+
+/*
+ExtractTicketDataFromReader works like ExtractTicketData, but it reads the CSV data from
+the specified reader instead of a file, so tickets can be parsed from any source such as
+an HTTP body or an in-memory buffer.
+*/
+func ExtractTicketDataFromReader(r io.Reader) ([]Ticket, error) {
+	return extractTickets(r, ExtractOptions{})
+}
+
+/*
+RowError describes a row of a CSV file that could not be parsed. Err already mentions the
+line number, so it is returned as is by Error.
+*/
+type RowError struct {
+	// Line is the line of the file where the row starts.
+	Line int
+
+	// Err is the reason why the row could not be parsed.
+	Err error
+}
+
+// Error implements the error interface.
+func (e RowError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the reason why the row could not be parsed.
+func (e RowError) Unwrap() error {
+	return e.Err
+}
+
+/*
+ExtractTicketDataLenient works like ExtractTicketData, but the rows that cannot be parsed
+are skipped instead of aborting the extraction. It returns the valid tickets together with
+one RowError per skipped row, in the order they appear in the file.
+
+The returned error is only set if the file cannot be read, is empty or has an invalid
+header; in that case no tickets or row errors are returned.
+*/
+func ExtractTicketDataLenient(filename string) ([]Ticket, []RowError, error) {
+	// Open the CSV file
+	file, r, err := openTicketFile(osFS{}, filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	// Collect the valid tickets and the errors of the invalid rows
+	var tickets []Ticket
+	var rowErrors []RowError
+	err = streamTickets(r, ExtractOptions{}, func(ticket Ticket) error {
+		tickets = append(tickets, ticket)
+		return nil
+	}, func(rowError RowError) error {
+		rowErrors = append(rowErrors, rowError)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tickets, rowErrors, nil
+}
+
+/*
+StreamTicketData reads the CSV data of the specified reader row by row and calls fn with
+each parsed ticket, so memory stays bounded no matter how large the input is. The format
+is the same accepted by ExtractTicketData.
+
+If fn returns an error, the reading stops and the error is returned. Parsing errors are
+returned as soon as the offending row is reached, after fn was called with the previous
+tickets.
+*/
+func StreamTicketData(r io.Reader, fn func(Ticket) error) error {
+	return streamTickets(r, ExtractOptions{}, fn, nil)
+}
+
+// extractTickets is a utility function that parses all the tickets of the specified reader.
+func extractTickets(r io.Reader, opts ExtractOptions) ([]Ticket, error) {
+	var tickets []Ticket
+
+	// Parse each record and add the ticket to the slice
+	err := streamTickets(r, opts, func(ticket Ticket) error {
+		tickets = append(tickets, ticket)
+		return nil
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+/*
+streamTickets reads the CSV data of the specified reader record by record and calls fn
+with each parsed ticket, so the tickets do not need to be kept in memory. If fn returns an
+error, the reading stops and the error is returned. The records are read and parsed with a
+recordReader, so the header row is handled as described there.
+
+If onRowError is nil, the reading stops at the first invalid row and its error is returned.
+Otherwise, invalid rows are skipped and reported to onRowError, and the reading only stops
+if onRowError returns an error. Errors that affect the whole file, such as an invalid
+header, always stop the reading.
+*/
+func streamTickets(r io.Reader, opts ExtractOptions, fn func(Ticket) error, onRowError func(RowError) error) error {
+	records, err := newRecordReader(r, opts)
+	if err != nil {
+		return err
+	}
+
+	// Line where each ticket id was first seen (only used in strict mode)
+	seenIDs := map[int]int{}
+
+	// Loop through each record
+	for {
+		fields, lineNumber, err := records.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			// Skip the malformed record if the row errors are collected
+			var parseErr *csv.ParseError
+			if onRowError == nil || !errors.As(err, &parseErr) {
+				return err
+			}
+			if err := onRowError(RowError{Line: parseErr.StartLine, Err: err}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Parse the record and check that the ticket ID was not seen before (strict mode only)
+		ticket, err := records.parseRecord(fields, lineNumber)
+		if err == nil && opts.StrictUniqueIDs && records.parseColumn["id"] {
+			err = checkUniqueID(seenIDs, ticket.id, lineNumber)
+		}
+
+		// Skip the invalid record if the row errors are collected
+		if err != nil {
+			if onRowError == nil {
+				return err
+			}
+			if err := onRowError(RowError{Line: lineNumber, Err: err}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := fn(ticket); err != nil {
+			return err
+		}
+	}
+}
+
+/*
+recordReader reads the CSV records of a ticket file one by one and parses them into tickets
+according to the extraction options.
+
+The records are read with encoding/csv, so quoted fields may contain commas, escaped
+quotes ("") and line breaks. If the id field of the first record is not an integer, the
+record is considered a header: it is skipped and the columns are mapped by their names,
+so they may appear in any order.
+*/
+type recordReader struct {
+	reader *csv.Reader
+	opts   ExtractOptions
+
+	// parseColumn holds the columns to parse and columnIndex the position of each column
+	parseColumn map[string]bool
+	columnIndex map[string]int
+
+	// expectedFields is the number of fields every record must have
+	expectedFields int
+
+	// records is the number of records read, including the header and the malformed ones
+	records int
+
+	// lineOffset is the number of lines of the file before the data of the reader
+	lineOffset int
+}
+
+/*
+newRecordReader is a utility function that creates a recordReader for the specified reader.
+It returns an error if a column of the options is unknown or if the layout is invalid.
+*/
+func newRecordReader(r io.Reader, opts ExtractOptions) (*recordReader, error) {
+	// Columns to parse (every column if not specified)
+	parseColumn := map[string]bool{}
+	for _, column := range ticketColumns {
+		parseColumn[column] = opts.Columns == nil
+	}
+	for _, column := range opts.Columns {
+		if _, exists := parseColumn[column]; !exists {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+		parseColumn[column] = true
+	}
+
+	// Position of each column in the records (the layout order if there is no header)
+	layout := DefaultColumnLayout()
+	if opts.Layout != nil {
+		layout = *opts.Layout
+	}
+	columnIndex, err := layout.columnIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	// Records must hold every column of the layout
+	expectedFields := 0
+	for _, position := range columnIndex {
+		if position >= expectedFields {
+			expectedFields = position + 1
+		}
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	if opts.Delimiter != 0 {
+		reader.Comma = opts.Delimiter
+	}
+
+	return &recordReader{
+		reader:         reader,
+		opts:           opts,
+		parseColumn:    parseColumn,
+		columnIndex:    columnIndex,
+		expectedFields: expectedFields,
+	}, nil
+}
+
+/*
+next returns the fields of the next record and the line of the file where it starts,
+skipping the header row. It returns io.EOF after the last record, or ErrEmptyFile if the
+data has no records at all. A record that cannot be split into fields is returned as a
+*csv.ParseError, after which the following records can still be read.
+*/
+func (rr *recordReader) next() ([]string, int, error) {
+	for {
+		fields, err := rr.reader.Read()
+		if err == io.EOF {
+			// If the file is empty, return an error
+			if rr.records == 0 {
+				return nil, 0, ErrEmptyFile
+			}
+			return nil, 0, io.EOF
+		}
+		rr.records++
+		if err != nil {
+			// Report the lines of the malformed record relative to the whole file
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				parseErr.StartLine += rr.lineOffset
+				parseErr.Line += rr.lineOffset
+			}
+			return nil, 0, err
+		}
+
+		// Line of the file where the record starts
+		lineNumber, _ := rr.reader.FieldPos(0)
+		lineNumber += rr.lineOffset
+
+		// Map the columns by name if the first record is a header (its id is not a number)
+		idIndex := rr.columnIndex["id"]
+		if rr.records == 1 && idIndex < len(fields) && !isNumeric(fields[idIndex]) {
+			rr.columnIndex, err = parseHeader(fields, rr.parseColumn)
+			if err != nil {
+				return nil, 0, err
+			}
+			rr.expectedFields = len(fields)
+			continue
+		}
+		return fields, lineNumber, nil
+	}
+}
+
+/*
+chunkReader returns a recordReader for a chunk of the same data that starts at the
+specified line. The columns are already mapped by rr, so the first record of the chunk is
+never considered a header, and an empty chunk is not an error.
+*/
+func (rr *recordReader) chunkReader(r io.Reader, firstLine int) *recordReader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comma = rr.reader.Comma
+
+	return &recordReader{
+		reader:         reader,
+		opts:           rr.opts,
+		parseColumn:    rr.parseColumn,
+		columnIndex:    rr.columnIndex,
+		expectedFields: rr.expectedFields,
+		records:        1,
+		lineOffset:     firstLine - 1,
+	}
+}
+
+/*
+parseRecord creates a ticket from the fields of a record and validates it with the options
+of the reader. Repeated ids are not checked, since that depends on the previous records, so
+it can be called concurrently once the header row was read.
+*/
+func (rr *recordReader) parseRecord(fields []string, lineNumber int) (Ticket, error) {
+	// Check the number of fields before indexing them
+	if len(fields) != rr.expectedFields {
+		return Ticket{}, fmt.Errorf("row %d: expected %d fields, got %d", lineNumber, rr.expectedFields, len(fields))
+	}
+
+	// Create a new ticket
+	ticket, err := parseTicket(fields, lineNumber, rr.columnIndex, rr.parseColumn)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	// Check that the email is a valid address (only if requested)
+	if rr.opts.ValidateEmails && rr.parseColumn["email"] {
+		if _, err := mail.ParseAddress(ticket.email); err != nil {
+			return Ticket{}, fieldError(lineNumber, "email", fmt.Errorf("invalid email %q: %w", ticket.email, err))
+		}
+	}
+
+	// Check that the price is plausible (only if requested)
+	if rr.opts.ValidatePrices && rr.parseColumn["ticket_price"] {
+		if ticket.ticketPrice < 0 {
+			return Ticket{}, fmt.Errorf("row %d, field 'ticket_price': negative price %d", lineNumber, ticket.ticketPrice)
+		}
+		if rr.opts.MaxPrice != 0 && ticket.ticketPrice > rr.opts.MaxPrice {
+			return Ticket{}, fmt.Errorf(
+				"row %d, field 'ticket_price': price %d is greater than %d",
+				lineNumber,
+				ticket.ticketPrice,
+				rr.opts.MaxPrice,
+			)
+		}
+	}
+	return ticket, nil
+}
+
+/*
+checkUniqueID is a utility function that returns an error if the specified ticket id is in
+seenIDs, which holds the line where each id was first seen. Otherwise, the id is added to
+seenIDs with the specified line.
+*/
+func checkUniqueID(seenIDs map[int]int, id, lineNumber int) error {
+	if firstLine, exists := seenIDs[id]; exists {
+		return fmt.Errorf("duplicate ticket id: %d at row %d (first seen at row %d)", id, lineNumber, firstLine)
+	}
+	seenIDs[id] = lineNumber
+	return nil
+}
+
+/*
+parseHeader is a utility function that returns the position of each known column in the
+specified header. Column names are compared case-insensitively and unknown columns are
+ignored. It returns an error if a column to parse is missing or if a column is repeated.
+*/
+func parseHeader(header []string, parseColumn map[string]bool) (map[string]int, error) {
+	columnIndex := map[string]int{}
+	for i, name := range header {
+		column := strings.ToLower(strings.TrimSpace(name))
+		if _, known := parseColumn[column]; !known {
+			continue
+		}
+		if _, exists := columnIndex[column]; exists {
+			return nil, fmt.Errorf("duplicate column %q in header", column)
+		}
+		columnIndex[column] = i
+	}
+
+	// Check that every column to parse is present
+	for _, column := range ticketColumns {
+		if _, exists := columnIndex[column]; parseColumn[column] && !exists {
+			return nil, fmt.Errorf("missing column %q in header", column)
+		}
+	}
+	return columnIndex, nil
+}
+
+/*
+parseTicket is a utility function that creates a ticket from the fields of a CSV record,
+using columnIndex to locate each column. Only the columns set in parseColumn are parsed,
+the rest are left with their zero value.
+*/
+func parseTicket(fields []string, lineNumber int, columnIndex map[string]int, parseColumn map[string]bool) (Ticket, error) {
+	var err error
+	ticket := Ticket{}
+
+	// Set the ticket ID
+	if parseColumn["id"] {
+		ticket.id, err = strconv.Atoi(fields[columnIndex["id"]])
+		if err != nil {
+			return Ticket{}, fieldError(lineNumber, "id", err)
+		}
+	}
+
+	// Set the ticket name
+	if parseColumn["name"] {
+		ticket.name = fields[columnIndex["name"]]
+	}
+
+	// Set the ticket email
+	if parseColumn["email"] {
+		ticket.email = fields[columnIndex["email"]]
+	}
+
+	// Set the ticket destination
+	if parseColumn["destination"] {
+		ticket.destination = fields[columnIndex["destination"]]
+	}
+
+	// Set the ticket departure time
+	if parseColumn["departure_time"] {
+		ticket.departureTime, err = parseDepartureTime(fields[columnIndex["departure_time"]])
+		if err != nil {
+			return Ticket{}, fieldError(lineNumber, "departure_time", err)
+		}
+	}
+
+	// Set the ticket ticket price
+	if parseColumn["ticket_price"] {
+		price := fields[columnIndex["ticket_price"]]
+		ticket.ticketPrice, err = strconv.Atoi(price)
+		if err != nil {
+			// A numeric email or destination suggests the columns of the row are shifted
+			emailIndex, hasEmail := columnIndex["email"]
+			destinationIndex, hasDestination := columnIndex["destination"]
+			if (hasEmail && isNumeric(fields[emailIndex])) ||
+				(hasDestination && isNumeric(fields[destinationIndex])) {
+				return Ticket{}, fmt.Errorf("%w; columns may be misaligned", fieldError(lineNumber, "ticket_price", err))
+			}
+			return Ticket{}, fieldError(lineNumber, "ticket_price", err)
+		}
+	}
+	return ticket, nil
+}
+
+/*
+fieldError is a utility function that wraps the specified parsing error with the row and
+the column of the field that failed, so callers can still inspect the original error with
+errors.Is and errors.As.
+*/
+func fieldError(lineNumber int, column string, err error) error {
+	return fmt.Errorf("row %d, field '%s': %w", lineNumber, column, err)
+}
+
+// departureTimeLayouts are the accepted formats of the departure_time column.
+var departureTimeLayouts = []string{"15:04", "2006-01-02 15:04", time.RFC3339}
+
+/*
+parseDepartureTime is a utility function that parses the specified departure time with the
+first layout of departureTimeLayouts that matches it. Time-only values (e.g. "17:11") have
+no date, so they land on January 1 of year 0.
+*/
+func parseDepartureTime(field string) (time.Time, error) {
+	var err error
+	for _, layout := range departureTimeLayouts {
+		var departureTime time.Time
+		departureTime, err = time.Parse(layout, field)
+		if err == nil {
+			return departureTime, nil
+		}
+	}
+
+	// Return the error of the last layout tried
+	return time.Time{}, err
+}
+
+// isNumeric is a utility function that checks if the specified field holds an integer.
+func isNumeric(field string) bool {
+	_, err := strconv.Atoi(strings.TrimSpace(field))
+	return err == nil
+}
+
+/*
+GetTotalTicketsByDestination search and count tickets based on the specified destination.
+It returns the number of tickets found. If the destination is not found, it returns an error.
+*/
+func GetTotalTicketsByDestination(data []Ticket, destination string) (int, error) {
+	// Obtain the tickets with the specified destination
+	tickets, err := FilterByDestination(data, destination)
+
+	// If the slice is empty or the destination is not found, return an error
+	if err != nil {
+		return 0, err
+	}
+
+	// Return the total number of tickets found
+	return len(tickets), nil
+}
+
+/*
+GetTotalTicketsByDestinationFold works like GetTotalTicketsByDestination, but the
+destinations are compared case-insensitively, so "china", "China" and "CHINA" are counted
+as the same destination.
+*/
+func GetTotalTicketsByDestinationFold(data []Ticket, destination string) (int, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return 0, ErrEmptyData
+	}
+
+	// Loop through each ticket
+	totalTickets := 0
+	for _, ticket := range data {
+		if strings.EqualFold(ticket.destination, destination) {
+			totalTickets++
+		}
+	}
+
+	// Return a error if the destination is not found
+	if totalTickets == 0 {
+		return 0, fmt.Errorf("%w %s", ErrDestinationNotFound, destination)
+	}
+	return totalTickets, nil
+}
+
+/*
+FilterByDestination returns the tickets with the specified destination.
+If the data is empty or the destination is not found, it returns an error.
+*/
+func FilterByDestination(data []Ticket, destination string) ([]Ticket, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// Loop through each ticket
+	var tickets []Ticket
+	for _, ticket := range data {
+		if ticket.destination == destination {
+			tickets = append(tickets, ticket)
+		}
+	}
+
+	// Return a error if the destination is not found
+	if len(tickets) == 0 {
+		return nil, fmt.Errorf("%w %s", ErrDestinationNotFound, destination)
+	}
+	return tickets, nil
+}
+
+/*
+CheckTimeBetweenLimits is a utility function that checks if the specified target hour
+is between the specified start hour and end hour. It returns true if the target hour
+is between the specified start hour and end hour. Otherwise, it returns false.
+
+The includeStart and includeEnd parameters control whether a target equal to the start
+or the end hour, respectively, is considered to be between them.
+
+If the start hour is greater than the end hour, it returns an error.
+*/
+func checkTimeBetweenLimits(target, start, end time.Time, includeStart, includeEnd bool) (bool, error) {
+	// If the start time is after the end time, return an error
+	if start.After(end) {
+		return false, ErrInvalidTimeRange
+	}
+
+	// Check if the target time is on one of the limits
+	if target.Equal(start) {
+		return includeStart, nil
+	}
+	if target.Equal(end) {
+		return includeEnd, nil
+	}
+
+	// Check if the target time is between the start and end time
+	if target.After(start) && target.Before(end) {
+		return true, nil
+	}
+
+	// Return false otherwise
+	return false, nil
+}
+
+/*
+checkTimeInPeriod is a utility function that checks if the specified target hour belongs
+to the period that starts at the start hour (included) and ends at the end hour (excluded).
+If the end hour is before the start hour, the period wraps past midnight, so it contains
+the hours from the start hour until midnight and from midnight until the end hour.
+*/
+func checkTimeInPeriod(target, start, end time.Time) bool {
+	// If the period wraps past midnight, split it in two ranges
+	if start.After(end) {
+		return !target.Before(start) || target.Before(end)
+	}
+
+	isInPeriod, _ := checkTimeBetweenLimits(target, start, end, true, false)
+	return isInPeriod
+}
+
+/*
+timeOfDay is a utility function that returns the clock time of the specified time on
+January 1 of year 0 (UTC), which is the date of the times parsed without a date, so times
+with and without a date can be compared by their time of day.
+*/
+func timeOfDay(t time.Time) time.Time {
+	return time.Date(0, time.January, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+/*
+Definition of lower and upper limits for each period. Each period includes its lower
+limit and excludes its upper limit. The evening wraps past midnight, so it ends at 0:00.
+*/
+var (
+	morningLowerLimit, _      = time.Parse("15:04", "7:00")
+	morningUpperLimit, _      = time.Parse("15:04", "13:00")
+	afternoonLowerLimit, _    = time.Parse("15:04", "13:00")
+	afternoonUpperLimit, _    = time.Parse("15:04", "20:00")
+	eveningLowerLimit, _      = time.Parse("15:04", "20:00")
+	eveningUpperLimit, _      = time.Parse("15:04", "0:00")
+	earlyMorningLowerLimit, _ = time.Parse("15:04", "0:00")
+	earlyMorningUpperLimit, _ = time.Parse("15:04", "7:00")
+)
+
+/*
+newPeriodCounts is a utility function that returns a map with a zero count for each
+period (morning, afternoon, evening and early_morning).
+*/
+func newPeriodCounts() map[string]int {
+	return map[string]int{
+		"morning":       0,
+		"afternoon":     0,
+		"evening":       0,
+		"early_morning": 0,
+	}
+}
+
+/*
+getPeriod is a utility function that returns the name of the period (morning, afternoon,
+evening or early_morning) that contains the specified departure time. Only the time of
+day is considered, so the date of the departure time is ignored. If the departure time does
+not belong to any period, it returns false as the second value.
+*/
+func getPeriod(departureTime time.Time) (string, bool) {
+	// Compare only the time of day with the limits, which have no date
+	departureTime = timeOfDay(departureTime)
+
+	isMorning := checkTimeInPeriod(departureTime, morningLowerLimit, morningUpperLimit)
+	isAfternoon := checkTimeInPeriod(departureTime, afternoonLowerLimit, afternoonUpperLimit)
+	isEvening := checkTimeInPeriod(departureTime, eveningLowerLimit, eveningUpperLimit)
+	isEarlyMorning := checkTimeInPeriod(departureTime, earlyMorningLowerLimit, earlyMorningUpperLimit)
+
+	switch {
+	case isMorning:
+		return "morning", true
+	case isAfternoon:
+		return "afternoon", true
+	case isEvening:
+		return "evening", true
+	case isEarlyMorning:
+		return "early_morning", true
+	}
+	return "", false
+}
+
+/*
+GetCountByPeriod receive a slice of Tickets structs and returns a map
+containing the total number of tickets for each period (morning, afternoon, evening and
+early_morning).
+
+The time ranges are as follows: morning: between 7:00 and 13:00, afternoon: between 13:00 and
+20:00, evening: between 20:00 and 00:00 and early morning: between 00:00 and 7:00.
+*/
+func GetCountByPeriod(data []Ticket) (map[string]int, error) {
+	countByPeriod := newPeriodCounts()
+
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// Loop through each ticket
+	for _, ticket := range data {
+		if period, ok := getPeriod(ticket.departureTime); ok {
+			countByPeriod[period]++
+		}
+	}
+	return countByPeriod, nil
+}
+
+// Period is a part of the day used to classify the departure times of the tickets.
+type Period int
+
+// Periods of the day, in chronological order.
+const (
+	EarlyMorning Period = iota
+	Morning
+	Afternoon
+	Evening
+)
+
+// periods are all the defined periods, in chronological order.
+var periods = []Period{EarlyMorning, Morning, Afternoon, Evening}
+
+/*
+String implements the fmt.Stringer interface. It returns the key used for the period by
+GetCountByPeriod (early_morning, morning, afternoon or evening).
+*/
+func (p Period) String() string {
+	switch p {
+	case EarlyMorning:
+		return "early_morning"
+	case Morning:
+		return "morning"
+	case Afternoon:
+		return "afternoon"
+	case Evening:
+		return "evening"
+	}
+	return fmt.Sprintf("Period(%d)", int(p))
+}
+
+/*
+GetCountByPeriodTyped works like GetCountByPeriod, but the returned map is keyed by Period
+instead of by the period names, so the keys are checked at compile time. The map always
+holds the four periods, even if no ticket departs in some of them.
+*/
+func GetCountByPeriodTyped(data []Ticket) (map[Period]int, error) {
+	countByName, err := GetCountByPeriod(data)
+	if err != nil {
+		return nil, err
+	}
+
+	countByPeriod := make(map[Period]int, len(periods))
+	for _, period := range periods {
+		countByPeriod[period] = countByName[period.String()]
+	}
+	return countByPeriod, nil
+}
+
+/*
+GetCountByCustomPeriods works like GetCountByPeriod, but the tickets are counted into the
+specified named ranges instead of the four fixed periods. Each range is given as its start
+and end time of day: the start is included and the end is excluded, and a range whose end
+is before its start wraps past midnight (e.g. 22:00 to 6:00). The dates of the range limits
+and of the departure times are ignored.
+
+The ranges may overlap, in which case a ticket is counted in every range that contains it.
+The returned map holds every range name, even if no ticket departs in it. It returns an
+error if the data is empty, if no range is specified or if a range starts and ends at the
+same time.
+*/
+func GetCountByCustomPeriods(data []Ticket, ranges map[string][2]time.Time) (map[string]int, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// If there are no ranges, return an error
+	if len(ranges) == 0 {
+		return nil, errors.New("no ranges specified")
+	}
+
+	// Validate the limits of each range
+	countByRange := make(map[string]int, len(ranges))
+	for name, limits := range ranges {
+		if timeOfDay(limits[0]).Equal(timeOfDay(limits[1])) {
+			return nil, fmt.Errorf("range %q must not start and end at the same time: %w", name, ErrInvalidTimeRange)
+		}
+		countByRange[name] = 0
+	}
+
+	// Loop through each ticket
+	for _, ticket := range data {
+		departureTime := timeOfDay(ticket.departureTime)
+		for name, limits := range ranges {
+			if checkTimeInPeriod(departureTime, timeOfDay(limits[0]), timeOfDay(limits[1])) {
+				countByRange[name]++
+			}
+		}
+	}
+	return countByRange, nil
+}
+
+/*
+CountByPeriodFromFile works like GetCountByPeriod, but it reads the tickets directly from
+the specified CSV file and counts them as they are parsed, without keeping all of them in
+memory. It is meant for files too large to be loaded with ExtractTicketData.
+
+If the file cannot be read or parsed, it returns an error.
+*/
+func CountByPeriodFromFile(filename string) (map[string]int, error) {
+	countByPeriod := newPeriodCounts()
+
+	// Open the CSV file
+	file, r, err := openTicketFile(osFS{}, filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// Count each ticket as soon as it is parsed
+	err = streamTickets(r, ExtractOptions{}, func(ticket Ticket) error {
+		if period, ok := getPeriod(ticket.departureTime); ok {
+			countByPeriod[period]++
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return countByPeriod, nil
+}
+
+// PeriodStat holds the number of tickets of a period and its percentage of all tickets.
+type PeriodStat struct {
+	Count   int
+	Percent float64
+}
+
+/*
+PeriodBreakdown receive a slice of Tickets structs and returns, for each period, the total
+number of tickets and the percentage (between 0 and 100) they represent of all the tickets.
+
+The periods and their time ranges are the same used by GetCountByPeriod. If the data is
+empty, it returns an error.
+*/
+func PeriodBreakdown(data []Ticket) (map[string]PeriodStat, error) {
+	// Obtain the total amount of tickets of each period
+	countByPeriod, err := GetCountByPeriod(data)
+
+	// If the data is empty, return an error
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate the percentage of each period
+	breakdown := make(map[string]PeriodStat, len(countByPeriod))
+	for period, count := range countByPeriod {
+		breakdown[period] = PeriodStat{
+			Count:   count,
+			Percent: float64(count) / float64(len(data)) * 100,
+		}
+	}
+	return breakdown, nil
+}
+
+/*
+AverageDestination calculates the percentage of all emitted tickets that have a certain destination.
+
+It returns the percentage of all emitted tickets that have a given destination, as a value between 0
+and 100 (e.g. 50 when half of the tickets have the destination). Earlier versions returned a fraction
+between 0 and 1 instead. If the destination is not found or if the data is empty, it returns an error.
+*/
+func AverageDestination(data []Ticket, destination string) (float64, error) {
+	// Obtain the total amount of tickets with the specified destination
+	targetTickets, err := GetTotalTicketsByDestination(data, destination)
+
+	// If the destination is not found or the data is empty, return an error
+	if err != nil {
+		return 0, err
+	}
+
+	// Otherwise, calculate the percentage of all emitted tickets with the specified destination
+	return float64(targetTickets) / float64(len(data)) * 100, nil
+}
+
+/*
+HighValueShare calculates the fraction of tickets whose price is equal to or greater than
+the specified threshold.
+
+It returns a value between 0 and 1. If the data is empty, it returns an error.
+*/
+func HighValueShare(data []Ticket, threshold int) (float64, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return 0, ErrEmptyData
+	}
+
+	// Count the tickets priced at or above the threshold
+	highValueTickets := 0
+	for _, ticket := range data {
+		if ticket.ticketPrice >= threshold {
+			highValueTickets++
+		}
+	}
+
+	return float64(highValueTickets) / float64(len(data)), nil
+}
+
+// ReconcileOptions holds the options that change how ReconcileTotals counts the expected tickets.
+type ReconcileOptions struct {
+	// Deduplicate makes the merged slice be expected to contain one ticket for each
+	// distinct ticket id found across all the parts, instead of the sum of the tickets of
+	// the parts. It is meant for merges that drop tickets repeated between parts.
+	Deduplicate bool
+}
+
+/*
+ReconcileTotals checks that the merged slice of tickets contains exactly as many tickets
+as the sum of all the parts that were merged into it, or as the number of distinct ticket
+ids of the parts if Deduplicate is enabled.
+
+If the totals do not match, it returns an error describing the mismatch.
+*/
+func ReconcileTotals(parts [][]Ticket, merged []Ticket, opts ReconcileOptions) error {
+	// Count the tickets of all the parts, or their distinct ids if they are deduplicated
+	expectedTotal := 0
+	ids := map[int]bool{}
+	for _, part := range parts {
+		expectedTotal += len(part)
+		for _, ticket := range part {
+			ids[ticket.id] = true
+		}
+	}
+	description := "tickets"
+	if opts.Deduplicate {
+		expectedTotal = len(ids)
+		description = "distinct tickets"
+	}
+
+	if len(merged) != expectedTotal {
+		return fmt.Errorf(
+			"merged tickets mismatch: expected %d %s from %d parts, got %d",
+			expectedTotal,
+			description,
+			len(parts),
+			len(merged),
+		)
+	}
+	return nil
+}
+
+/*
+AveragePriceByDestinationInWindow calculates the average price of the tickets with the
+specified destination whose departure time of day is between start and end (both
+inclusive). The dates of the departure times and of the limits are ignored, as in
+GetTicketsInTimeWindow.
+
+It returns an error if the data is empty, if the start time is after the end time, if the
+destination is not found or if no ticket of the destination departs within the window.
+*/
+func AveragePriceByDestinationInWindow(data []Ticket, destination string, start, end time.Time) (float64, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return 0, ErrEmptyData
+	}
+	start, end = timeOfDay(start), timeOfDay(end)
+
+	// If the start time is after the end time, return an error
+	if start.After(end) {
+		return 0, ErrInvalidTimeRange
+	}
+
+	// If the destination is not found, return an error
+	if _, err := GetTotalTicketsByDestination(data, destination); err != nil {
+		return 0, err
+	}
+
+	// Sum the prices of the tickets of the destination that depart within the window
+	totalPrice := 0
+	totalTickets := 0
+	for _, ticket := range data {
+		if ticket.destination != destination {
+			continue
+		}
+		departureTime := timeOfDay(ticket.departureTime)
+		if departureTime.Before(start) || departureTime.After(end) {
+			continue
+		}
+		totalPrice += ticket.ticketPrice
+		totalTickets++
+	}
+
+	// Return an error if no ticket departs within the window
+	if totalTickets == 0 {
+		return 0, fmt.Errorf(
+			"no tickets found for destination %s between %s and %s",
+			destination,
+			start.Format("15:04"),
+			end.Format("15:04"),
+		)
+	}
+
+	return float64(totalPrice) / float64(totalTickets), nil
+}
+
+/*
+ToMapByID returns the specified tickets in a map keyed by their id, which is useful to
+join ticket data with other datasets.
+
+If the data is empty or two tickets share the same id (which a map would silently
+drop), it returns an error.
+*/
+func ToMapByID(data []Ticket) (map[int]Ticket, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	ticketsByID := make(map[int]Ticket, len(data))
+	for _, ticket := range data {
+		// Return an error if the id was already added
+		if _, exists := ticketsByID[ticket.id]; exists {
+			return nil, fmt.Errorf("duplicate ticket id %d", ticket.id)
+		}
+		ticketsByID[ticket.id] = ticket
+	}
+	return ticketsByID, nil
+}
+
+/*
+GetTicketByID returns the ticket with the specified id. If several tickets share the id,
+the first one in the slice is returned, consistently with BuildIDIndex.
+
+If the data is empty or no ticket has the id, it returns an error. For repeated lookups
+over the same data, build an index once with BuildIDIndex instead.
+*/
+func GetTicketByID(data []Ticket, id int) (Ticket, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return Ticket{}, ErrEmptyData
+	}
+
+	// Loop through each ticket until the id is found
+	for _, ticket := range data {
+		if ticket.id == id {
+			return ticket, nil
+		}
+	}
+	return Ticket{}, fmt.Errorf("no ticket found with id %d", id)
+}
+
+/*
+BuildIDIndex returns the specified tickets in a map keyed by their id, for O(1) lookups.
+If several tickets share the same id, the first one in the slice wins and the rest are
+ignored. Use ToMapByID to detect duplicate ids instead.
+*/
+func BuildIDIndex(data []Ticket) map[int]Ticket {
+	index := make(map[int]Ticket, len(data))
+	for _, ticket := range data {
+		if _, exists := index[ticket.id]; !exists {
+			index[ticket.id] = ticket
+		}
+	}
+	return index
+}
+
+/*
+getEmailDomain is a utility function that returns the domain of the specified email
+address in lower case. If the email does not contain an @, it returns an empty string.
+*/
+func getEmailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at == -1 {
+		return ""
+	}
+	return strings.ToLower(email[at+1:])
+}
+
+/*
+FilterByEmailDomain returns the tickets whose email belongs to the specified domain. The
+comparison is case-insensitive.
+
+If the data is empty, it returns an error. If no ticket matches, it returns an empty slice.
+*/
+func FilterByEmailDomain(data []Ticket, domain string) ([]Ticket, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// Loop through each ticket and keep the ones with the specified domain
+	filtered := []Ticket{}
+	for _, ticket := range data {
+		if getEmailDomain(ticket.email) == strings.ToLower(domain) {
+			filtered = append(filtered, ticket)
+		}
+	}
+	return filtered, nil
+}
+
+/*
+isComplete is a utility function that checks if all the fields of the specified ticket
+are populated: non-empty name, email and destination, non-zero departure time and
+non-negative price.
+*/
+func isComplete(ticket Ticket) bool {
+	return ticket.name != "" &&
+		ticket.email != "" &&
+		ticket.destination != "" &&
+		!ticket.departureTime.IsZero() &&
+		ticket.ticketPrice >= 0
+}
+
+/*
+IncompleteTickets returns the tickets that have missing or default data, that is, an empty
+name, email or destination, a zero departure time or a negative price.
+
+If the data is empty, it returns an error. If every ticket is complete, it returns an
+empty slice.
+*/
+func IncompleteTickets(data []Ticket) ([]Ticket, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	incomplete := []Ticket{}
+	for _, ticket := range data {
+		if !isComplete(ticket) {
+			incomplete = append(incomplete, ticket)
+		}
+	}
+	return incomplete, nil
+}
+
+/*
+CompletenessScore calculates the fraction of tickets that have all their fields populated,
+as defined by IncompleteTickets. It returns a value between 0 and 1, where 1 means that
+every ticket is complete.
+
+If the data is empty, it returns an error.
+*/
+func CompletenessScore(data []Ticket) (float64, error) {
+	// Obtain the tickets with missing or default data
+	incomplete, err := IncompleteTickets(data)
+
+	// If the data is empty, return an error
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(len(data)-len(incomplete)) / float64(len(data)), nil
+}
+
+// trip is the composite key of an email and a destination.
+type trip struct {
+	email       string
+	destination string
+}
+
+/*
+UniqueTripCount counts the distinct combinations of email and destination, which is the
+number of different trips booked regardless of how many tickets each one has. Emails are
+compared case-insensitively.
+
+If the data is empty, it returns an error.
+*/
+func UniqueTripCount(data []Ticket) (int, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return 0, ErrEmptyData
+	}
+
+	// Collect the distinct trips
+	trips := map[trip]bool{}
+	for _, ticket := range data {
+		trips[trip{strings.ToLower(ticket.email), ticket.destination}] = true
+	}
+	return len(trips), nil
+}
+
+/*
+GetCountByTimeBin divides the day into bins of the specified number of minutes and counts
+the departures in each of them. The keys of the returned map are the start time of each
+bin formatted as HH:MM (e.g. "14:30"), and every bin of the day is present even if no
+ticket departs in it.
+
+It returns an error if the data is empty or if binMinutes does not divide a day
+(1440 minutes) evenly.
+*/
+func GetCountByTimeBin(data []Ticket, binMinutes int) (map[string]int, error) {
+	const minutesPerDay = 24 * 60
+
+	// If the bin size does not divide the day evenly, return an error
+	if binMinutes <= 0 || minutesPerDay%binMinutes != 0 {
+		return nil, fmt.Errorf("bin size of %d minutes does not divide a day evenly", binMinutes)
+	}
+
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// Initialize every bin of the day
+	countByBin := make(map[string]int, minutesPerDay/binMinutes)
+	for start := 0; start < minutesPerDay; start += binMinutes {
+		countByBin[fmt.Sprintf("%02d:%02d", start/60, start%60)] = 0
+	}
+
+	// Loop through each ticket and count it in the bin that contains its departure time
+	for _, ticket := range data {
+		minutes := ticket.departureTime.Hour()*60 + ticket.departureTime.Minute()
+		start := minutes - minutes%binMinutes
+		countByBin[fmt.Sprintf("%02d:%02d", start/60, start%60)]++
+	}
+	return countByBin, nil
+}
+
+/*
+CoBookedDestinations returns, for each destination, the other destinations booked by the
+same passengers (identified by their email, compared case-insensitively). The lists are
+sorted alphabetically, and destinations that share no passengers have an empty list.
+
+If the data is empty, it returns an error.
+*/
+func CoBookedDestinations(data []Ticket) (map[string][]string, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// Group the destinations booked by each passenger
+	destinationsByEmail := map[string]map[string]bool{}
+	for _, ticket := range data {
+		email := strings.ToLower(ticket.email)
+		if destinationsByEmail[email] == nil {
+			destinationsByEmail[email] = map[string]bool{}
+		}
+		destinationsByEmail[email][ticket.destination] = true
+	}
+
+	// Cross-list the destinations of each passenger
+	related := map[string]map[string]bool{}
+	for _, destinations := range destinationsByEmail {
+		for destination := range destinations {
+			if related[destination] == nil {
+				related[destination] = map[string]bool{}
+			}
+			for other := range destinations {
+				if other != destination {
+					related[destination][other] = true
+				}
+			}
+		}
+	}
+
+	// Convert the sets into sorted slices
+	coBooked := make(map[string][]string, len(related))
+	for destination, others := range related {
+		list := make([]string, 0, len(others))
+		for other := range others {
+			list = append(list, other)
+		}
+		sort.Strings(list)
+		coBooked[destination] = list
+	}
+	return coBooked, nil
+}
+
+// sumTicketPrices is a utility function that returns the sum of the prices of the specified tickets.
+func sumTicketPrices(data []Ticket) int {
+	total := 0
+	for _, ticket := range data {
+		total += ticket.ticketPrice
+	}
+	return total
+}
+
+/*
+countUniquePassengers is a utility function that returns the number of distinct passengers
+of the specified tickets, identified by their email (compared case-insensitively).
+*/
+func countUniquePassengers(data []Ticket) int {
+	emails := map[string]bool{}
+	for _, ticket := range data {
+		emails[strings.ToLower(ticket.email)] = true
+	}
+	return len(emails)
+}
+
+/*
+RevenuePerPassenger calculates the total revenue divided by the number of distinct
+passengers (identified by their email).
+
+If the data is empty, it returns an error.
+*/
+func RevenuePerPassenger(data []Ticket) (float64, error) {
+	// Obtain the total revenue
+	totalRevenue, err := GetTotalRevenue(data)
+
+	// If the slice is empty, return an error
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(totalRevenue) / float64(countUniquePassengers(data)), nil
+}
+
+/*
+ValidateSchema checks that every row of the specified CSV file matches the documented
+field types: one field per column, integer id and ticket_price, a departure_time in one of
+the formats accepted by ExtractTicketData and an email containing an @. As in
+ExtractTicketData, the file may start with a header row, in which case the columns are
+located by their names.
+
+It returns nil if the whole file is valid, or an error describing the first violation
+with its line number otherwise.
+*/
+func ValidateSchema(filename string) error {
+	// Open the CSV file
+	file, r, err := openTicketFile(osFS{}, filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	records, err := newRecordReader(r, ExtractOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Check the fields of each record
+	for {
+		fields, lineNumber, err := records.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(fields) != records.expectedFields {
+			return fmt.Errorf("line %d: expected %d fields, got %d", lineNumber, records.expectedFields, len(fields))
+		}
+		field := func(column string) string {
+			return fields[records.columnIndex[column]]
+		}
+		if _, err := strconv.Atoi(field("id")); err != nil {
+			return fmt.Errorf("line %d: id %q is not an integer", lineNumber, field("id"))
+		}
+		if !strings.Contains(field("email"), "@") {
+			return fmt.Errorf("line %d: email %q does not contain an @", lineNumber, field("email"))
+		}
+		if _, err := parseDepartureTime(field("departure_time")); err != nil {
+			return fmt.Errorf("line %d: departure_time %q is not a valid time", lineNumber, field("departure_time"))
+		}
+		if _, err := strconv.Atoi(field("ticket_price")); err != nil {
+			return fmt.Errorf("line %d: ticket_price %q is not an integer", lineNumber, field("ticket_price"))
+		}
+	}
+}
+
+/*
+EarlyMorningShare calculates the fraction of tickets that depart in the early morning
+(between 00:00 and 7:00). It returns a value between 0 and 1.
+
+If the data is empty, it returns an error.
+*/
+func EarlyMorningShare(data []Ticket) (float64, error) {
+	// Obtain the breakdown of tickets by period
+	breakdown, err := PeriodBreakdown(data)
+
+	// If the data is empty, return an error
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(breakdown["early_morning"].Count) / float64(len(data)), nil
+}
+
+// DestStat holds the aggregated statistics of the tickets of a single destination.
+type DestStat struct {
+	Count            int
+	TotalRevenue     int
+	AveragePrice     float64
+	UniquePassengers int
+}
+
+/*
+DestinationStats groups the tickets by destination and returns, for each of them, the
+number of tickets, the total revenue, the average price and the number of distinct
+passengers (identified by their email, compared case-insensitively), computed as in
+RevenuePerPassenger.
+
+If the data is empty, it returns an error.
+*/
+func DestinationStats(data []Ticket) (map[string]DestStat, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// Group the tickets by destination
+	ticketsByDestination := map[string][]Ticket{}
+	for _, ticket := range data {
+		ticketsByDestination[ticket.destination] = append(ticketsByDestination[ticket.destination], ticket)
+	}
+
+	// Calculate the stats of each destination
+	stats := make(map[string]DestStat, len(ticketsByDestination))
+	for destination, tickets := range ticketsByDestination {
+		totalRevenue := sumTicketPrices(tickets)
+		stats[destination] = DestStat{
+			Count:            len(tickets),
+			TotalRevenue:     totalRevenue,
+			AveragePrice:     float64(totalRevenue) / float64(len(tickets)),
+			UniquePassengers: countUniquePassengers(tickets),
+		}
+	}
+	return stats, nil
+}
+
+/*
+NextDeparture returns the ticket whose departure time of day is the soonest at or after
+the clock time of now, together with the time left until it departs. If no ticket departs
+later in the day, it wraps around midnight and returns the earliest departure of the next
+day. When several tickets depart at the same time, the first one in the slice is returned.
+
+If the data is empty, it returns an error.
+*/
+func NextDeparture(data []Ticket, now time.Time) (Ticket, time.Duration, error) {
+	const day = 24 * time.Hour
+
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return Ticket{}, 0, ErrEmptyData
+	}
+
+	// Find the ticket with the shortest wait, wrapping around midnight
+	var next Ticket
+	shortestWait := day
+	for _, ticket := range data {
+		wait := (timeOfDay(ticket.departureTime).Sub(timeOfDay(now)) + day) % day
+		if wait < shortestWait {
+			next = ticket
+			shortestWait = wait
+		}
+	}
+	return next, shortestWait, nil
+}
+
+/*
+PeriodFillRate calculates, for each period, its number of tickets divided by the number of
+tickets of the busiest period, so the busiest period has a value of 1 and the rest a value
+between 0 and 1.
+
+If the data is empty, it returns an error.
+*/
+func PeriodFillRate(data []Ticket) (map[string]float64, error) {
+	// Obtain the total amount of tickets of each period
+	countByPeriod, err := GetCountByPeriod(data)
+
+	// If the data is empty, return an error
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the count of the busiest period
+	busiest := 0
+	for _, count := range countByPeriod {
+		if count > busiest {
+			busiest = count
+		}
+	}
+
+	// Calculate the fill rate of each period (all zero if no period has tickets)
+	fillRate := make(map[string]float64, len(countByPeriod))
+	for period, count := range countByPeriod {
+		fillRate[period] = 0
+		if busiest > 0 {
+			fillRate[period] = float64(count) / float64(busiest)
+		}
+	}
+	return fillRate, nil
+}
+
+/*
+VolumeChange calculates the percentage change in the number of tickets between an old and
+a new dataset (e.g. 50 for a 50% increase, -25 for a 25% decrease).
+
+If the old dataset is empty, the change cannot be calculated and it returns an error.
+*/
+func VolumeChange(oldData, newData []Ticket) (float64, error) {
+	// If the old slice is empty, return an error (division by zero)
+	if len(oldData) == 0 {
+		return 0, fmt.Errorf("%w in the old dataset", ErrEmptyData)
+	}
+
+	return float64(len(newData)-len(oldData)) / float64(len(oldData)) * 100, nil
+}
+
+/*
+ConcurrentDepartures finds the tickets that share both destination and exact departure
+time, which may indicate a capacity clash. The keys of the returned map have the format
+"destination@HH:MM", or "destination@" followed by an RFC3339 timestamp for departure times
+with a date, so tickets at the same time on different days do not clash. Only slots with
+two or more tickets are included.
+
+If the data is empty, it returns an error.
+*/
+func ConcurrentDepartures(data []Ticket) (map[string][]Ticket, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// Group the tickets by destination and departure time
+	ticketsBySlot := map[string][]Ticket{}
+	for _, ticket := range data {
+		slot := ticket.destination + "@" + formatDepartureTime(ticket.departureTime)
+		ticketsBySlot[slot] = append(ticketsBySlot[slot], ticket)
+	}
+
+	// Remove the slots with a single ticket
+	for slot, tickets := range ticketsBySlot {
+		if len(tickets) < 2 {
+			delete(ticketsBySlot, slot)
+		}
+	}
+	return ticketsBySlot, nil
+}
+
+/*
+AveragePriceByPeriodAndDestination calculates the average ticket price for every
+combination of period and destination in a single pass. The returned map is keyed by
+period and then by destination, and combinations without tickets are not included.
+
+The periods are the same used by GetCountByPeriod. If the data is empty, it returns
+an error.
+*/
+func AveragePriceByPeriodAndDestination(data []Ticket) (map[string]map[string]float64, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// Sum the prices and count the tickets of each combination
+	totalPrice := map[string]map[string]int{}
+	totalTickets := map[string]map[string]int{}
+	for _, ticket := range data {
+		period, ok := getPeriod(ticket.departureTime)
+		if !ok {
+			continue
+		}
+		if totalPrice[period] == nil {
+			totalPrice[period] = map[string]int{}
+			totalTickets[period] = map[string]int{}
+		}
+		totalPrice[period][ticket.destination] += ticket.ticketPrice
+		totalTickets[period][ticket.destination]++
+	}
+
+	// Calculate the average price of each combination
+	averagePrice := make(map[string]map[string]float64, len(totalPrice))
+	for period, prices := range totalPrice {
+		averagePrice[period] = make(map[string]float64, len(prices))
+		for destination, price := range prices {
+			averagePrice[period][destination] = float64(price) / float64(totalTickets[period][destination])
+		}
+	}
+	return averagePrice, nil
+}
+
+/*
+DestinationsForRevenueShare finds the smallest set of top-earning destinations whose
+combined revenue reaches the specified share (between 0 and 1) of the total revenue. It
+returns how many destinations are needed and their names, sorted by revenue in descending
+order (ties are broken alphabetically).
+
+It returns an error if the data is empty, if targetShare is not in (0, 1] or if the total
+revenue is zero.
+*/
+func DestinationsForRevenueShare(data []Ticket, targetShare float64) (int, []string, error) {
+	// If the target share is out of range, return an error
+	if targetShare <= 0 || targetShare > 1 {
+		return 0, nil, fmt.Errorf("target share %v must be greater than 0 and at most 1", targetShare)
+	}
+
+	// Obtain the revenue of each destination
+	stats, err := DestinationStats(data)
+
+	// If the data is empty, return an error
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// If there is no revenue at all, no share can be reached
+	totalRevenue := sumTicketPrices(data)
+	if totalRevenue == 0 {
+		return 0, nil, errors.New("total revenue is zero")
+	}
+
+	// Sort the destinations by revenue (descending) and name
+	destinations := make([]string, 0, len(stats))
+	for destination := range stats {
+		destinations = append(destinations, destination)
+	}
+	sort.Slice(destinations, func(i, j int) bool {
+		first, second := stats[destinations[i]], stats[destinations[j]]
+		if first.TotalRevenue != second.TotalRevenue {
+			return first.TotalRevenue > second.TotalRevenue
+		}
+		return destinations[i] < destinations[j]
+	})
+
+	// Add destinations until the target share is reached
+	accumulated := 0
+	for i, destination := range destinations {
+		accumulated += stats[destination].TotalRevenue
+		if float64(accumulated) >= targetShare*float64(totalRevenue) {
+			return i + 1, destinations[:i+1], nil
+		}
+	}
+	return len(destinations), destinations, nil
+}
+
+/*
+RemoveImplausibleTimes drops the tickets whose departure time of day is outside the window
+between earliest and latest (both inclusive). Only the clock time of the limits is used.
+It returns the remaining tickets and the number of tickets removed, without modifying the
+specified slice.
+
+If earliest is after latest, it returns an error.
+*/
+func RemoveImplausibleTimes(data []Ticket, earliest, latest time.Time) ([]Ticket, int, error) {
+	earliest, latest = timeOfDay(earliest), timeOfDay(latest)
+
+	// If the earliest time is after the latest time, return an error
+	if earliest.After(latest) {
+		return nil, 0, ErrInvalidTimeRange
+	}
+
+	// Loop through each ticket and keep the ones within the window
+	filtered := []Ticket{}
+	for _, ticket := range data {
+		departure := timeOfDay(ticket.departureTime)
+		if !departure.Before(earliest) && !departure.After(latest) {
+			filtered = append(filtered, ticket)
+		}
+	}
+	return filtered, len(data) - len(filtered), nil
+}
+
+/*
+PriceHourCorrelation calculates the Pearson correlation coefficient between the ticket
+price and the departure hour of day (including the fraction of the hour given by the
+minutes). It returns a value between -1 and 1, where a positive value means that later
+departures tend to cost more.
+
+It returns an error if the data is empty or if the prices or the hours have no variance.
+*/
+func PriceHourCorrelation(data []Ticket) (float64, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return 0, ErrEmptyData
+	}
+
+	// Hours elapsed since midnight until the departure time of day
+	midnight := timeOfDay(time.Time{})
+	hourOfDay := func(ticket Ticket) float64 {
+		return timeOfDay(ticket.departureTime).Sub(midnight).Hours()
+	}
+
+	// Calculate the mean price and hour
+	var meanPrice, meanHour float64
+	for _, ticket := range data {
+		meanPrice += float64(ticket.ticketPrice)
+		meanHour += hourOfDay(ticket)
+	}
+	meanPrice /= float64(len(data))
+	meanHour /= float64(len(data))
+
+	// Calculate the covariance and the variances
+	var covariance, priceVariance, hourVariance float64
+	for _, ticket := range data {
+		priceDiff := float64(ticket.ticketPrice) - meanPrice
+		hourDiff := hourOfDay(ticket) - meanHour
+		covariance += priceDiff * hourDiff
+		priceVariance += priceDiff * priceDiff
+		hourVariance += hourDiff * hourDiff
+	}
+
+	// If any variable is constant, the correlation is undefined
+	if priceVariance == 0 || hourVariance == 0 {
+		return 0, errors.New("correlation is undefined when prices or hours have zero variance")
+	}
+
+	return covariance / math.Sqrt(priceVariance*hourVariance), nil
+}
+
+/*
+GetCountByWeekday counts the departures of each day of the week. It only makes sense for
+datasets whose departure times carry a full date: tickets parsed from a time-only value
+(such as "17:11") have no date, and in that case it returns an error.
+
+It also returns an error if the data is empty.
+*/
+func GetCountByWeekday(data []Ticket) (map[time.Weekday]int, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	countByWeekday := map[time.Weekday]int{}
+	for _, ticket := range data {
+		// Time-only values are parsed into year 0, so they have no meaningful weekday
+		if ticket.departureTime.Year() == 0 {
+			return nil, fmt.Errorf("ticket %d has no departure date", ticket.id)
+		}
+		countByWeekday[ticket.departureTime.Weekday()]++
+	}
+	return countByWeekday, nil
+}
+
+/*
+BreakEvenTickets calculates how many tickets of the specified destination, sold at the
+destination's average price, are needed to cover the specified fixed cost. It also reports
+whether the tickets already booked for the destination reach that number.
+
+It returns an error if the data is empty, if the destination is not found, if the fixed
+cost is negative or if the average price of the destination is zero.
+*/
+func BreakEvenTickets(data []Ticket, destination string, fixedCost int) (int, bool, error) {
+	// If the fixed cost is negative, return an error
+	if fixedCost < 0 {
+		return 0, false, errors.New("fixed cost must not be negative")
+	}
+
+	// Obtain the current number of tickets of the destination
+	totalTickets, err := GetTotalTicketsByDestination(data, destination)
+
+	// If the destination is not found or the data is empty, return an error
+	if err != nil {
+		return 0, false, err
+	}
+
+	// Obtain the average price of the destination
+	stats, _ := DestinationStats(data)
+	averagePrice := stats[destination].AveragePrice
+	if averagePrice == 0 {
+		return 0, false, fmt.Errorf("average price of destination %s is zero", destination)
+	}
+
+	// Calculate the number of tickets needed to cover the fixed cost
+	needed := int(math.Ceil(float64(fixedCost) / averagePrice))
+	return needed, totalTickets >= needed, nil
+}
+
+/*
+LongestDepartureGap sorts the departure times of day of the specified tickets and returns
+the longest gap between two consecutive departures, together with the departure times
+that bound it. If several gaps have the same length, the earliest one is returned. The
+specified slice is not modified.
+
+It returns an error if there are less than two tickets.
+*/
+func LongestDepartureGap(data []Ticket) (time.Duration, time.Time, time.Time, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return 0, time.Time{}, time.Time{}, ErrEmptyData
+	}
+
+	// A gap needs at least two tickets
+	if len(data) < 2 {
+		return 0, time.Time{}, time.Time{}, errors.New("at least two tickets are needed")
+	}
+
+	// Sort a copy of the departure times by time of day
+	departures := make([]time.Time, len(data))
+	for i, ticket := range data {
+		departures[i] = ticket.departureTime
+	}
+	sort.SliceStable(departures, func(i, j int) bool {
+		return timeOfDay(departures[i]).Before(timeOfDay(departures[j]))
+	})
+
+	// Find the longest gap between consecutive departures
+	longest := time.Duration(-1)
+	var start, end time.Time
+	for i := 1; i < len(departures); i++ {
+		gap := timeOfDay(departures[i]).Sub(timeOfDay(departures[i-1]))
+		if gap > longest {
+			longest = gap
+			start = departures[i-1]
+			end = departures[i]
+		}
+	}
+	return longest, start, end, nil
+}
+
+/*
+DestinationPriceIndex calculates, for each destination, its average ticket price divided by
+the average price of all the tickets. A value of 1 means the destination is priced like
+the average, while greater values mean it is more expensive.
+
+It returns an error if the data is empty or if the overall average price is zero.
+*/
+func DestinationPriceIndex(data []Ticket) (map[string]float64, error) {
+	// Obtain the average price of each destination
+	stats, err := DestinationStats(data)
+
+	// If the data is empty, return an error
+	if err != nil {
+		return nil, err
+	}
+
+	// Obtain the overall average price
+	overallAverage, _ := GetAveragePrice(data)
+	if overallAverage == 0 {
+		return nil, errors.New("overall average price is zero")
+	}
+
+	priceIndex := make(map[string]float64, len(stats))
+	for destination, stat := range stats {
+		priceIndex[destination] = stat.AveragePrice / overallAverage
+	}
+	return priceIndex, nil
+}
+
+/*
+DomesticInternationalRevenue splits the total revenue between domestic tickets (whose
+destination equals the specified home country) and international tickets (the rest).
+
+If the data is empty, it returns an error.
+*/
+func DomesticInternationalRevenue(data []Ticket, home string) (domestic, international int, err error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return 0, 0, ErrEmptyData
+	}
+
+	// Loop through each ticket and add its price to the corresponding revenue
+	for _, ticket := range data {
+		if ticket.destination == home {
+			domestic += ticket.ticketPrice
+		} else {
+			international += ticket.ticketPrice
+		}
+	}
+	return domestic, international, nil
+}
+
+/*
+GetTotalRevenue calculates the sum of the prices of all the tickets.
 
-The CSV file must be formatted as follows:
-id,name,email,destination,departure_time,ticket_price.
+The revenue is returned as an int, which is 64 bits wide on the platforms this package
+targets (amd64 and arm64), so it does not overflow for any realistic dataset. On 32-bit
+platforms the sum overflows above 2,147,483,647.
+
+If the data is empty, it returns an error.
 */
-func ExtractTicketData(filename string) ([]Ticket, error) {
-	var tickets []Ticket
+func GetTotalRevenue(data []Ticket) (int, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return 0, ErrEmptyData
+	}
 
-	// Open the CSV file
-	file, err := os.ReadFile(filename)
+	return sumTicketPrices(data), nil
+}
+
+/*
+GetRevenueByDestination groups the tickets by destination and returns the sum of the
+prices of each group. Only the destinations present in the data appear in the map.
+
+If the data is empty, it returns an error.
+*/
+func GetRevenueByDestination(data []Ticket) (map[string]int, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	revenueByDestination := map[string]int{}
+	for _, ticket := range data {
+		revenueByDestination[ticket.destination] += ticket.ticketPrice
+	}
+	return revenueByDestination, nil
+}
+
+/*
+GetAveragePrice calculates the mean price of the tickets.
+
+If the data is empty, it returns an error.
+*/
+func GetAveragePrice(data []Ticket) (float64, error) {
+	// Obtain the total revenue of the tickets
+	totalRevenue, err := GetTotalRevenue(data)
+
+	// If the data is empty, return an error
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(totalRevenue) / float64(len(data)), nil
+}
+
+/*
+GetMostPopularDestination returns the destination with the most tickets and its number of
+tickets. If several destinations have the same number of tickets, the alphabetically
+smallest one is returned, so the result is always the same for the same data.
+
+If the data is empty, it returns an error.
+*/
+func GetMostPopularDestination(data []Ticket) (string, int, error) {
+	// Obtain the destination ranked first
+	top, err := GetTopNDestinations(data, 1)
+
+	// If the data is empty, return an error
+	if err != nil {
+		return "", 0, err
+	}
+
+	return top[0].Destination, top[0].Count, nil
+}
+
+// DestinationCount holds the number of tickets of a destination.
+type DestinationCount struct {
+	Destination string
+	Count       int
+}
+
+/*
+GetTopNDestinations returns the n destinations with the most tickets, sorted by number of
+tickets in descending order. Destinations with the same number of tickets are sorted
+alphabetically. If n is greater than the number of distinct destinations, all of them
+are returned.
+
+It returns an error if n is not positive or if the data is empty.
+*/
+func GetTopNDestinations(data []Ticket, n int) ([]DestinationCount, error) {
+	// If n is not positive, return an error
+	if n <= 0 {
+		return nil, fmt.Errorf("n must be positive, got %d", n)
+	}
+
+	// Count the tickets of each destination
+	countByDestination, err := CountByDestination(data)
+
+	// If the slice is empty, return an error
 	if err != nil {
 		return nil, err
 	}
 
-	// Split the file into lines
-	lines := strings.Split(string(file), "\n")
+	// Sort the destinations by count (descending) and name
+	ranking := make([]DestinationCount, 0, len(countByDestination))
+	for destination, count := range countByDestination {
+		ranking = append(ranking, DestinationCount{destination, count})
+	}
+	sort.Slice(ranking, func(i, j int) bool {
+		if ranking[i].Count != ranking[j].Count {
+			return ranking[i].Count > ranking[j].Count
+		}
+		return ranking[i].Destination < ranking[j].Destination
+	})
+
+	if n > len(ranking) {
+		n = len(ranking)
+	}
+	return ranking[:n], nil
+}
+
+/*
+FilterByPriceRange returns the tickets whose price is between minPrice and maxPrice (both
+inclusive). If no ticket is within the range, it returns an empty slice.
 
-	// Remove the last line from the file (blank line) if exists
-	if lines[len(lines)-1] == "" {
-		lines = lines[:len(lines)-1]
+If minPrice is greater than maxPrice, it returns an error.
+*/
+func FilterByPriceRange(data []Ticket, minPrice, maxPrice int) ([]Ticket, error) {
+	// If the range is inverted, return an error
+	if minPrice > maxPrice {
+		return nil, errors.New("minimum price must not be greater than maximum price")
 	}
 
-	// If the file is empty, return a nil value and an error
-	if len(file) == 0 {
-		return nil, errors.New("empty CSV file")
+	// Loop through each ticket and keep the ones within the range
+	filtered := []Ticket{}
+	for _, ticket := range data {
+		if ticket.ticketPrice >= minPrice && ticket.ticketPrice <= maxPrice {
+			filtered = append(filtered, ticket)
+		}
 	}
+	return filtered, nil
+}
 
-	// Loop through each line
-	for _, line := range lines {
-		// Split the line into fields
-		fields := strings.Split(line, ",")
+/*
+GetCheapestTicket returns the ticket with the lowest price. If several tickets share the
+lowest price, the one with the lowest id is returned.
 
-		// Create a new ticket
-		ticket := Ticket{}
+If the data is empty, it returns an error.
+*/
+func GetCheapestTicket(data []Ticket) (Ticket, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return Ticket{}, ErrEmptyData
+	}
 
-		// Set the ticket ID
-		ticket.id, err = strconv.Atoi(fields[0])
-		if err != nil {
-			return nil, err
+	// Loop through each ticket and keep the cheapest one
+	cheapest := data[0]
+	for _, ticket := range data[1:] {
+		if ticket.ticketPrice < cheapest.ticketPrice ||
+			(ticket.ticketPrice == cheapest.ticketPrice && ticket.id < cheapest.id) {
+			cheapest = ticket
 		}
+	}
+	return cheapest, nil
+}
+
+/*
+GetMostExpensiveTicket returns the ticket with the highest price. If several tickets share
+the highest price, the one with the lowest id is returned.
 
-		// Set the ticket name
-		ticket.name = fields[1]
+If the data is empty, it returns an error.
+*/
+func GetMostExpensiveTicket(data []Ticket) (Ticket, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return Ticket{}, ErrEmptyData
+	}
 
-		// Set the ticket email
-		ticket.email = fields[2]
+	// Loop through each ticket and keep the most expensive one
+	mostExpensive := data[0]
+	for _, ticket := range data[1:] {
+		if ticket.ticketPrice > mostExpensive.ticketPrice ||
+			(ticket.ticketPrice == mostExpensive.ticketPrice && ticket.id < mostExpensive.id) {
+			mostExpensive = ticket
+		}
+	}
+	return mostExpensive, nil
+}
 
-		// Set the ticket destination
-		ticket.destination = fields[3]
+/*
+GetMedianPrice calculates the median price of the specified tickets. For an even number of
+tickets, it returns the average of the two middle prices. The prices are sorted in a copy,
+so the specified slice is not modified.
 
-		// Set the ticket departure time
-		ticket.departureTime, err = time.Parse("15:04", fields[4])
-		if err != nil {
-			return nil, err
+If the data is empty, it returns an error.
+*/
+func GetMedianPrice(data []Ticket) (float64, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return 0, ErrEmptyData
+	}
+
+	// Sort a copy of the prices
+	prices := make([]int, len(data))
+	for i, ticket := range data {
+		prices[i] = ticket.ticketPrice
+	}
+	sort.Ints(prices)
+
+	middle := len(prices) / 2
+	if len(prices)%2 == 0 {
+		return float64(prices[middle-1]+prices[middle]) / 2, nil
+	}
+	return float64(prices[middle]), nil
+}
+
+/*
+GetPriceStandardDeviation calculates the population standard deviation of the prices of
+the specified tickets, i.e. the squared deviations from the mean are divided by the number
+of tickets (not by the number of tickets minus one), since the data is the whole set of
+tickets and not a sample.
+
+If the data is empty, it returns an error.
+*/
+func GetPriceStandardDeviation(data []Ticket) (float64, error) {
+	// Obtain the mean price of the tickets
+	mean, err := GetAveragePrice(data)
+
+	// If the data is empty, return an error
+	if err != nil {
+		return 0, err
+	}
+
+	// Sum the squared deviations from the mean
+	sumSquares := 0.0
+	for _, ticket := range data {
+		deviation := float64(ticket.ticketPrice) - mean
+		sumSquares += deviation * deviation
+	}
+	return math.Sqrt(sumSquares / float64(len(data))), nil
+}
+
+/*
+GetUniqueDestinations returns the distinct destinations of the specified tickets, sorted
+alphabetically. Destinations are compared exactly, so "china" and "China" are different.
+
+If the data is empty, it returns an error.
+*/
+func GetUniqueDestinations(data []Ticket) ([]string, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// Loop through each ticket and keep the destinations not seen before
+	seen := map[string]bool{}
+	var destinations []string
+	for _, ticket := range data {
+		if !seen[ticket.destination] {
+			seen[ticket.destination] = true
+			destinations = append(destinations, ticket.destination)
 		}
+	}
 
-		// Set the ticket ticket price
-		ticket.ticketPrice, err = strconv.Atoi(fields[5])
-		if err != nil {
-			return nil, err
+	sort.Strings(destinations)
+	return destinations, nil
+}
+
+/*
+CountByDestination returns the number of tickets of each destination, counted in a single
+pass over the data. It is equivalent to calling GetTotalTicketsByDestination for every
+destination, but much faster on large datasets.
+
+If the data is empty, it returns an error.
+*/
+func CountByDestination(data []Ticket) (map[string]int, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// Loop through each ticket
+	countByDestination := map[string]int{}
+	for _, ticket := range data {
+		countByDestination[ticket.destination]++
+	}
+	return countByDestination, nil
+}
+
+/*
+GetTicketsByEmail returns all the tickets booked with the specified email. The emails are
+compared case-insensitively, so "TMC0@Scribd.com" matches "tmc0@scribd.com".
+
+If the data is empty or no ticket matches the email, it returns an error.
+*/
+func GetTicketsByEmail(data []Ticket, email string) ([]Ticket, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
+
+	// Loop through each ticket
+	var tickets []Ticket
+	for _, ticket := range data {
+		if strings.EqualFold(ticket.email, email) {
+			tickets = append(tickets, ticket)
 		}
+	}
 
-		// Add the ticket to the slice
-		tickets = append(tickets, ticket)
+	// Return a error if the email is not found
+	if len(tickets) == 0 {
+		return nil, errors.New("no tickets found for email " + email)
 	}
 	return tickets, nil
 }
 
 /*
-GetTotalTicketsByDestination search and count tickets based on the specified destination.
-It returns the number of tickets found. If the destination is not found, it returns an error.
+SearchTicketsByName returns the tickets whose passenger name contains the specified query.
+The comparison is case-insensitive, so "mckee" matches "Padget McKee".
+
+If the data is empty or no name contains the query, it returns an error.
 */
-func GetTotalTicketsByDestination(data []Ticket, destination string) (int, error) {
-	totalTickets := 0
+func SearchTicketsByName(data []Ticket, query string) ([]Ticket, error) {
 	// If the slice is empty, return an error
 	if len(data) == 0 {
-		return totalTickets, errors.New("no tickets found")
+		return nil, ErrEmptyData
 	}
 
 	// Loop through each ticket
+	lowerQuery := strings.ToLower(query)
+	var tickets []Ticket
 	for _, ticket := range data {
-		if ticket.destination == destination {
-			totalTickets++
+		if strings.Contains(strings.ToLower(ticket.name), lowerQuery) {
+			tickets = append(tickets, ticket)
 		}
 	}
 
-	// Return a error if the destination is not found
-	if totalTickets == 0 {
-		return 0, errors.New("no tickets found for destination " + destination)
+	// Return a error if no name matches the query
+	if len(tickets) == 0 {
+		return nil, fmt.Errorf("no tickets found for name %q", query)
 	}
+	return tickets, nil
+}
 
-	// Return the total number of tickets found
-	return totalTickets, nil
+// Summary holds the headline statistics of a set of tickets (see Summarize).
+type Summary struct {
+	TotalTickets int
+	TotalRevenue int
+	AveragePrice float64
+
+	// MostPopularDestination is chosen as in GetMostPopularDestination.
+	MostPopularDestination string
 }
 
 /*
-CheckTimeBetweenLimits is a utility function that checks if the specified target hour
-is between the specified start hour and end hour. It returns true if the target hour
-is between the specified start hour and end hour. Otherwise, it returns false.
+Summarize returns the headline statistics of the specified tickets: the number of
+tickets, the total revenue, the average price and the most popular destination. They
+match the results of GetTotalRevenue, GetAveragePrice and GetMostPopularDestination.
 
-If the start hour is greater than the end hour, it returns an error.
+If the data is empty, it returns an error.
+*/
+func Summarize(data []Ticket) (Summary, error) {
+	// Obtain the destination ranked first
+	mostPopular, _, err := GetMostPopularDestination(data)
+
+	// If the slice is empty, return an error
+	if err != nil {
+		return Summary{}, err
+	}
+
+	totalRevenue := sumTicketPrices(data)
+	return Summary{
+		TotalTickets:           len(data),
+		TotalRevenue:           totalRevenue,
+		AveragePrice:           float64(totalRevenue) / float64(len(data)),
+		MostPopularDestination: mostPopular,
+	}, nil
+}
+
+/*
+DestinationShare returns, for each destination, its fraction of the total number of
+tickets. Unlike AverageDestination, the values are fractions between 0 and 1 (not
+percentages), so they add up to 1.
+
+If the data is empty, it returns an error.
+*/
+func DestinationShare(data []Ticket) (map[string]float64, error) {
+	// Count the tickets of each destination
+	countByDestination, err := CountByDestination(data)
+
+	// If the slice is empty, return an error
+	if err != nil {
+		return nil, err
+	}
+
+	shareByDestination := make(map[string]float64, len(countByDestination))
+	for destination, count := range countByDestination {
+		shareByDestination[destination] = float64(count) / float64(len(data))
+	}
+	return shareByDestination, nil
+}
+
+/*
+GetTicketsInTimeWindow returns the tickets whose departure time of day is within the window
+between start and end. Both limits are included, so a ticket departing exactly at start or
+at end is returned. The dates of the departure times and of the limits are ignored. If no
+ticket departs within the window, it returns an empty slice.
+
+If start is after end, it returns an error.
 */
-func checkTimeBetweenLimits(target, start, end time.Time) (bool, error) {
+func GetTicketsInTimeWindow(data []Ticket, start, end time.Time) ([]Ticket, error) {
+	start, end = timeOfDay(start), timeOfDay(end)
+
 	// If the start time is after the end time, return an error
 	if start.After(end) {
-		return false, errors.New("start time must be before end time")
+		return nil, ErrInvalidTimeRange
 	}
 
-	// Check if the target time is between the start and end time
-	if target.After(start) && target.Before(end) {
-		return true, nil
+	// Loop through each ticket and keep the ones departing within the window
+	tickets := []Ticket{}
+	for _, ticket := range data {
+		isInWindow, _ := checkTimeBetweenLimits(timeOfDay(ticket.departureTime), start, end, true, true)
+		if isInWindow {
+			tickets = append(tickets, ticket)
+		}
 	}
+	return tickets, nil
+}
 
-	// Return false otherwise
-	return false, nil
+/*
+GetBusiestPeriod returns the name of the period (as used by GetCountByPeriod) with the most
+departures and its number of tickets. If several periods have the same number of tickets,
+the alphabetically smallest name is returned, so the result is always the same for the
+same data.
+
+If the data is empty, it returns an error.
+*/
+func GetBusiestPeriod(data []Ticket) (string, int, error) {
+	// Count the tickets of each period
+	countByPeriod, err := GetCountByPeriod(data)
+
+	// If the slice is empty, return an error
+	if err != nil {
+		return "", 0, err
+	}
+
+	// Choose the period with the most tickets (the alphabetically smallest on ties)
+	busiest := ""
+	mostTickets := -1
+	for period, count := range countByPeriod {
+		if count > mostTickets || (count == mostTickets && period < busiest) {
+			busiest = period
+			mostTickets = count
+		}
+	}
+	return busiest, mostTickets, nil
 }
 
 /*
-GetCountByPeriod receive a slice of Tickets structs and returns a map
-containing the total number of tickets for the specified period (morning, afternoon, evening,
-early morning).
+GetAveragePriceByDestination calculates the average ticket price of each destination. Only
+the destinations with at least one ticket appear in the result, so there is never a
+division by zero.
 
-The time ranges are as follows: morning: between 7:00 and 13:00, afternoon: between 13:00 and
-20:00, evening: between 20:00 and 00:00 and early morning: between 00:00 and 7:00.
+If the data is empty, it returns an error.
 */
-func GetCountByPeriod(data []Ticket) (map[string]int, error) {
-	var countByPeriod = map[string]int{
-		"morning":       0,
-		"evening":       0,
-		"night":         0,
-		"early_morning": 0,
+func GetAveragePriceByDestination(data []Ticket) (map[string]float64, error) {
+	// Obtain the statistics of each destination
+	stats, err := DestinationStats(data)
+
+	// If the slice is empty, return an error
+	if err != nil {
+		return nil, err
+	}
+
+	averageByDestination := make(map[string]float64, len(stats))
+	for destination, stat := range stats {
+		averageByDestination[destination] = stat.AveragePrice
 	}
+	return averageByDestination, nil
+}
+
+/*
+PriceHistogram counts the tickets in each price band of the specified size. The result maps
+the lower bound of each band to its number of tickets, e.g. with a bucket size of 100 a
+ticket of 785 is counted in the band 700, which holds the prices from 700 to 799. Only the
+bands with at least one ticket appear in the result.
 
+If the data is empty or the bucket size is not positive, it returns an error.
+*/
+func PriceHistogram(data []Ticket, bucketSize int) (map[int]int, error) {
 	// If the slice is empty, return an error
 	if len(data) == 0 {
-		return nil, errors.New("no tickets found")
+		return nil, ErrEmptyData
 	}
 
-	// Definition of lower and upper limits for each period
-	morningLowerLimit, _ := time.Parse("15:04:05", "6:59:59")
-	morningUpperLimit, _ := time.Parse("15:04:05", "13:00:00")
-	eveningLowerLimit, _ := time.Parse("15:04:05", "12:59:59")
-	eveningUpperLimit, _ := time.Parse("15:04:05", "20:00:00")
-	nightLowerLimit, _ := time.Parse("15:04:05", "19:59:59")
-	nightUpperLimit, _ := time.Parse("15:04:05", "23:59:59")
-	earlyMorningLowerLimit, _ := time.Parse("15:04:05", "0:00:00")
-	earlyMorningUpperLimit, _ := time.Parse("15:04:05", "7:00:00")
+	// If the bucket size is not positive, return an error
+	if bucketSize <= 0 {
+		return nil, fmt.Errorf("bucket size must be positive, got %d", bucketSize)
+	}
 
-	// Loop through each ticket
+	histogram := map[int]int{}
 	for _, ticket := range data {
-		departureTime := ticket.departureTime
-		isMorning, _ := checkTimeBetweenLimits(
-			departureTime,
-			morningLowerLimit,
-			morningUpperLimit,
-		)
-		isEvening, _ := checkTimeBetweenLimits(
-			departureTime,
-			eveningLowerLimit,
-			eveningUpperLimit,
-		)
-		isNight, _ := checkTimeBetweenLimits(
-			departureTime,
-			nightLowerLimit,
-			nightUpperLimit,
-		)
-		isEarlyMorning, _ := checkTimeBetweenLimits(
-			departureTime,
-			earlyMorningLowerLimit,
-			earlyMorningUpperLimit,
-		)
-		if isMorning {
-			countByPeriod["morning"]++
-		}
-		if isEvening {
-			countByPeriod["evening"]++
-		}
-		if isNight {
-			countByPeriod["night"]++
-		}
-		if isEarlyMorning {
-			countByPeriod["early_morning"]++
+		// Round the price down to a multiple of the bucket size, also for negative prices
+		lowerBound := ticket.ticketPrice / bucketSize * bucketSize
+		if lowerBound > ticket.ticketPrice {
+			lowerBound -= bucketSize
 		}
+		histogram[lowerBound]++
 	}
-	return countByPeriod, nil
+	return histogram, nil
 }
 
 /*
-AverageDestination calculates the percentage of all emitted tickets that have a certain destination.
+DeparturesByHour counts the tickets departing in each hour of the day, from 0 to 23. Only
+the hours with at least one departure appear in the result.
 
-It returns the percentage of all emitted tickets that have a given destination. If the destination is not
-found or if the data is empty, it returns an error.
+If the data is empty, it returns an error.
 */
-func AverageDestination(data []Ticket, destination string) (float64, error) {
-	// Obtain the total amount of tickets with the specified destination
-	targetTickets, err := GetTotalTicketsByDestination(data, destination)
+func DeparturesByHour(data []Ticket) (map[int]int, error) {
+	// If the slice is empty, return an error
+	if len(data) == 0 {
+		return nil, ErrEmptyData
+	}
 
-	// If the destination is not found or the data is empty, return an error
-	if err != nil {
-		return 0, err
+	departuresByHour := map[int]int{}
+	for _, ticket := range data {
+		departuresByHour[ticket.departureTime.Hour()]++
 	}
+	return departuresByHour, nil
+}
 
-	// Otherwise, calculate the percentage of all emitted tickets with the specified destination
-	return float64(targetTickets) / float64(len(data)), nil
+/*
+MergeTickets concatenates the tickets of a and b, dropping the tickets whose id was already
+seen. The first occurrence of each id wins: a ticket of a is kept over a ticket of b with the
+same id, and within each slice the earlier ticket is kept over the later ones. The order of
+the kept tickets is preserved.
+
+It returns a new slice, so neither a nor b is modified.
+*/
+func MergeTickets(a, b []Ticket) []Ticket {
+	merged := make([]Ticket, 0, len(a)+len(b))
+	seen := make(map[int]bool, len(a)+len(b))
+	for _, data := range [][]Ticket{a, b} {
+		for _, ticket := range data {
+			// Skip the tickets whose id was already added
+			if seen[ticket.id] {
+				continue
+			}
+			seen[ticket.id] = true
+			merged = append(merged, ticket)
+		}
+	}
+	return merged
 }
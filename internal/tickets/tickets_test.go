@@ -1,12 +1,110 @@
 package tickets
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// writeTestFile writes the specified content to a temporary file and returns its name.
+func writeTestFile(t *testing.T, content string) string {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), "tickets.csv")
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+// writeGzipTestFile compresses the content of the specified file into a temporary .gz file and returns its path.
+func writeGzipTestFile(t *testing.T, source string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	if _, err := gzipWriter.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "tickets.csv.gz")
+	if err := os.WriteFile(filename, compressed.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestTicketGetters(t *testing.T) {
+	t.Run("Read each field of a parsed ticket", func(t *testing.T) {
+		filename := "./ticket_test.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		ticket := ticketSlice[0]
+		expectedTicketTime, _ := time.Parse("15:04", "17:11")
+
+		assert.Equal(t, 1, ticket.ID())
+		assert.Equal(t, "Tait Mc Caughan", ticket.Name())
+		assert.Equal(t, "tmc0@scribd.com", ticket.Email())
+		assert.Equal(t, "Finland", ticket.Destination())
+		assert.Equal(t, expectedTicketTime, ticket.DepartureTime())
+		assert.Equal(t, 785, ticket.TicketPrice())
+	})
+}
+
+func TestTicketEqual(t *testing.T) {
+	departureTime, _ := time.Parse("15:04", "17:11")
+	ticket := Ticket{1, "Tait Mc Caughan", "tmc0@scribd.com", "Finland", departureTime, 785}
+
+	t.Run("Tickets with the same data", func(t *testing.T) {
+		other := ticket
+		other.departureTime = departureTime.In(time.FixedZone("UTC-3", -3*60*60))
+
+		assert.True(t, ticket.Equal(other))
+	})
+
+	t.Run("Tickets with different data", func(t *testing.T) {
+		other := ticket
+		other.ticketPrice = 786
+
+		assert.False(t, ticket.Equal(other))
+	})
+}
+
+func TestHeaderRow(t *testing.T) {
+	t.Run("Header fields in canonical order", func(t *testing.T) {
+		expectedHeader := []string{"id", "name", "email", "destination", "departure_time", "ticket_price"}
+
+		header := HeaderRow()
+
+		assert.Equal(t, expectedHeader, header)
+	})
+
+	t.Run("Returned header is a copy", func(t *testing.T) {
+		header := HeaderRow()
+		header[0] = "ticket_id"
+
+		assert.Equal(t, "id", HeaderRow()[0])
+	})
+}
+
 func TestExtractTickedData(t *testing.T) {
 	t.Run("Open inexistent tickets file", func(t *testing.T) {
 		filename := "./inexistent_file.csv"
@@ -17,6 +115,23 @@ func TestExtractTickedData(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("Error mentions the path of the file", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "missing", "tickets.csv")
+
+		tickets, err := ExtractTicketData(filename)
+
+		assert.Nil(t, tickets)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		assert.Contains(t, err.Error(), filename)
+	})
+
+	t.Run("Open an empty filename", func(t *testing.T) {
+		tickets, err := ExtractTicketData("")
+
+		assert.Nil(t, tickets)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
 	t.Run("Open a empty tickets file", func(t *testing.T) {
 		filename := "./empty_ticket_test.csv"
 
@@ -26,6 +141,19 @@ func TestExtractTickedData(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("Open a zero-byte tickets file", func(t *testing.T) {
+		filename := writeTestFile(t, "")
+
+		var tickets []Ticket
+		var err error
+		assert.NotPanics(t, func() {
+			tickets, err = ExtractTicketData(filename)
+		})
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, "empty CSV file")
+	})
+
 	t.Run("Open a valid tickets file", func(t *testing.T) {
 		filename := "./ticket_test.csv"
 
@@ -48,135 +176,3261 @@ func TestExtractTickedData(t *testing.T) {
 	})
 }
 
-func TestGetTotalTicketsByDestination(t *testing.T) {
-	t.Run("Search in empty ticket slice", func(t *testing.T) {
-		destination := "China"
-		var ticketSlice []Ticket
-		expectedTotalTickets := 0
+func TestExtractTicketDataMalformedRows(t *testing.T) {
+	validRows := "1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n" +
+		"2,Padget McKee,pmckee1@hexun.com,China,20:19,537\n"
 
-		totalTickets, err := GetTotalTicketsByDestination(ticketSlice, destination)
+	t.Run("Truncated row", func(t *testing.T) {
+		filename := writeTestFile(t, validRows+"3,Yalonda Jermyn,yjermyn2@omniture.com,China\n")
 
-		assert.Equal(t, expectedTotalTickets, totalTickets)
-		assert.Error(t, err)
+		var tickets []Ticket
+		var err error
+		assert.NotPanics(t, func() {
+			tickets, err = ExtractTicketData(filename)
+		})
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, "row 3: expected 6 fields, got 4")
 	})
 
-	t.Run("Search in valid ticket slice", func(t *testing.T) {
-		filename := "./ticket_test.csv"
-		destination := "Finland"
-		ticketSlice, _ := ExtractTicketData(filename)
-		expectedTotalTickets := 1
+	t.Run("Row with extra fields", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785,extra\n")
 
-		totalTickets, err := GetTotalTicketsByDestination(ticketSlice, destination)
-		assert.Equal(t, expectedTotalTickets, totalTickets)
+		tickets, err := ExtractTicketData(filename)
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, "row 1: expected 6 fields, got 7")
+	})
+}
+
+func TestExtractTicketDataQuotedFields(t *testing.T) {
+	t.Run("Name with a quoted comma", func(t *testing.T) {
+		filename := writeTestFile(t, "1,\"Smith, Jr.\",smith@scribd.com,Finland,17:11,785\n")
+
+		expectedTicketTime, _ := time.Parse("15:04", "17:11")
+		expectedData := []Ticket{
+			{1, "Smith, Jr.", "smith@scribd.com", "Finland", expectedTicketTime, 785},
+		}
+
+		data, err := ExtractTicketData(filename)
+
+		assert.Equal(t, expectedData, data)
 		assert.NoError(t, err)
 	})
 
-	t.Run("Search in valid ticket slice (0 results)", func(t *testing.T) {
-		filename := "./ticket_test.csv"
-		destination := "The Moon"
-		ticketSlice, _ := ExtractTicketData(filename)
-		expectedTotalTickets := 0
+	t.Run("Destination with escaped quotes", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,\"The \"\"Big\"\" Apple\",17:11,785\n")
 
-		totalTickets, err := GetTotalTicketsByDestination(ticketSlice, destination)
-		assert.Equal(t, expectedTotalTickets, totalTickets)
-		assert.Error(t, err)
+		data, err := ExtractTicketData(filename)
+
+		assert.Len(t, data, 1)
+		assert.Equal(t, `The "Big" Apple`, data[0].destination)
+		assert.NoError(t, err)
 	})
 }
 
-func TestCheckTimeBetweenLimits(t *testing.T) {
-	t.Run("Lower limit greater than upper limit", func(t *testing.T) {
-		targetTime, _ := time.Parse("15:04", "17:11")
-		lowerLimit, _ := time.Parse("15:04", "18:11")
-		upperLimit, _ := time.Parse("15:04", "16:11")
+func TestExtractTicketDataMisalignedPrice(t *testing.T) {
+	validRow := "1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n"
 
-		result, err := checkTimeBetweenLimits(targetTime, lowerLimit, upperLimit)
-		assert.False(t, result)
+	t.Run("Row with shifted columns", func(t *testing.T) {
+		filename := writeTestFile(t, validRow+"2,Padget McKee,537,pmckee1@hexun.com,20:19,China\n")
+
+		tickets, err := ExtractTicketData(filename)
+
+		var numErr *strconv.NumError
+		assert.Nil(t, tickets)
+		assert.EqualError(
+			t,
+			err,
+			`row 2, field 'ticket_price': strconv.Atoi: parsing "China": invalid syntax; columns may be misaligned`,
+		)
+		assert.ErrorAs(t, err, &numErr)
+	})
+
+	t.Run("Row with a non-numeric price", func(t *testing.T) {
+		filename := writeTestFile(t, validRow+"2,Padget McKee,pmckee1@hexun.com,China,20:19,abc\n")
+
+		tickets, err := ExtractTicketData(filename)
+
+		assert.Nil(t, tickets)
 		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), "misaligned")
 	})
+}
 
-	t.Run("Target is between lower and upper limit", func(t *testing.T) {
-		targetTime, _ := time.Parse("15:04", "17:11")
-		lowerLimit, _ := time.Parse("15:04", "16:11")
-		upperLimit, _ := time.Parse("15:04", "18:11")
+func TestExtractTicketDataHeader(t *testing.T) {
+	expectedTicketTime, _ := time.Parse("15:04", "17:11")
+	expectedData := []Ticket{
+		{1, "Tait Mc Caughan", "tmc0@scribd.com", "Finland", expectedTicketTime, 785},
+	}
 
-		result, err := checkTimeBetweenLimits(targetTime, lowerLimit, upperLimit)
+	t.Run("Header absent", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n")
 
-		assert.True(t, result)
+		data, err := ExtractTicketData(filename)
+
+		assert.Equal(t, expectedData, data)
 		assert.NoError(t, err)
 	})
 
-	t.Run("Target is before lower limit", func(t *testing.T) {
-		targetTime, _ := time.Parse("15:04", "15:11")
-		lowerLimit, _ := time.Parse("15:04", "16:11")
-		upperLimit, _ := time.Parse("15:04", "18:11")
+	t.Run("Header present", func(t *testing.T) {
+		filename := writeTestFile(t, "id,name,email,destination,departure_time,ticket_price\n"+
+			"1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n")
 
-		result, err := checkTimeBetweenLimits(targetTime, lowerLimit, upperLimit)
+		data, err := ExtractTicketData(filename)
 
-		assert.False(t, result)
+		assert.Equal(t, expectedData, data)
 		assert.NoError(t, err)
 	})
 
-	t.Run("Target is after upper limit", func(t *testing.T) {
-		targetTime, _ := time.Parse("15:04", "19:11")
-		lowerLimit, _ := time.Parse("15:04", "16:11")
-		upperLimit, _ := time.Parse("15:04", "18:11")
+	t.Run("Header with reordered columns", func(t *testing.T) {
+		filename := writeTestFile(t, "Ticket_Price,Destination,ID,Departure_Time,Email,Name\n"+
+			"785,Finland,1,17:11,tmc0@scribd.com,Tait Mc Caughan\n")
 
-		result, err := checkTimeBetweenLimits(targetTime, lowerLimit, upperLimit)
+		data, err := ExtractTicketData(filename)
 
-		assert.False(t, result)
+		assert.Equal(t, expectedData, data)
 		assert.NoError(t, err)
 	})
+
+	t.Run("Header with a missing column", func(t *testing.T) {
+		filename := writeTestFile(t, "id,name,email,destination,departure_time\n"+
+			"1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11\n")
+
+		data, err := ExtractTicketData(filename)
+
+		assert.Nil(t, data)
+		assert.EqualError(t, err, `missing column "ticket_price" in header`)
+	})
+
+	t.Run("Header with a duplicate column", func(t *testing.T) {
+		filename := writeTestFile(t, "id,name,email,destination,departure_time,ticket_price,id\n"+
+			"1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785,1\n")
+
+		data, err := ExtractTicketData(filename)
+
+		assert.Nil(t, data)
+		assert.EqualError(t, err, `duplicate column "id" in header`)
+	})
 }
 
-func TestGetCountByPeriod(t *testing.T) {
-	t.Run("Search in empty ticket slice", func(t *testing.T) {
-		var ticketSlice []Ticket
+func TestParseDepartureTime(t *testing.T) {
+	t.Run("Parse a time-only row", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n")
 
-		count, err := GetCountByPeriod(ticketSlice)
+		tickets, err := ExtractTicketData(filename)
 
-		assert.Nil(t, count)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(0, time.January, 1, 17, 11, 0, 0, time.UTC), tickets[0].departureTime)
+	})
+
+	t.Run("Parse a full date-time row", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,2024-03-15 17:11,785\n"+
+			"2,Padget McKee,pmckee1@hexun.com,China,2024-03-16T08:30:00Z,537\n")
+
+		tickets, err := ExtractTicketData(filename)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.March, 15, 17, 11, 0, 0, time.UTC), tickets[0].departureTime)
+		assert.Equal(t, time.Date(2024, time.March, 16, 8, 30, 0, 0, time.UTC), tickets[1].departureTime)
+	})
+
+	t.Run("Classify a full date-time row by its time of day", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,2024-03-15 17:11,785\n")
+		tickets, _ := ExtractTicketData(filename)
+
+		period, ok := getPeriod(tickets[0].departureTime)
+
+		assert.Equal(t, "afternoon", period)
+		assert.True(t, ok)
+	})
+
+	t.Run("Parse an invalid departure time", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,15/03/2024,785\n")
+
+		tickets, err := ExtractTicketData(filename)
+
+		assert.Nil(t, tickets)
 		assert.Error(t, err)
 	})
+}
+
+func TestNewTicket(t *testing.T) {
+	departureTime, _ := time.Parse("15:04", "17:11")
+
+	t.Run("Create a valid ticket", func(t *testing.T) {
+		filename := "./ticket_test.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		ticket, err := NewTicket(1, "Tait Mc Caughan", "tmc0@scribd.com", "Finland", departureTime, 785)
+
+		assert.Equal(t, ticketSlice[0], ticket)
+		assert.NoError(t, err)
+	})
 
-	t.Run("Search in valid ticket slice", func(t *testing.T) {
-		filename := "./ticket_test_2.csv"
+	t.Run("Create a ticket with a non-positive id", func(t *testing.T) {
+		ticket, err := NewTicket(0, "Tait Mc Caughan", "tmc0@scribd.com", "Finland", departureTime, 785)
+
+		assert.Equal(t, Ticket{}, ticket)
+		assert.EqualError(t, err, "id must be positive, got 0")
+	})
+
+	t.Run("Create a ticket with an empty name", func(t *testing.T) {
+		ticket, err := NewTicket(1, "  ", "tmc0@scribd.com", "Finland", departureTime, 785)
+
+		assert.Equal(t, Ticket{}, ticket)
+		assert.EqualError(t, err, "name must not be empty")
+	})
+
+	t.Run("Create a ticket with an invalid email", func(t *testing.T) {
+		ticket, err := NewTicket(1, "Tait Mc Caughan", "not-an-email", "Finland", departureTime, 785)
+
+		assert.Equal(t, Ticket{}, ticket)
+		assert.EqualError(t, err, `invalid email "not-an-email"`)
+	})
+
+	t.Run("Create a ticket with a negative price", func(t *testing.T) {
+		ticket, err := NewTicket(1, "Tait Mc Caughan", "tmc0@scribd.com", "Finland", departureTime, -1)
+
+		assert.Equal(t, Ticket{}, ticket)
+		assert.EqualError(t, err, "price must not be negative, got -1")
+	})
+}
+
+func TestValidateTickets(t *testing.T) {
+	t.Run("Validate clean ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
 		ticketSlice, _ := ExtractTicketData(filename)
 
-		expectedCount := map[string]int{
-			"morning":       1,
-			"evening":       1,
-			"night":         1,
-			"early_morning": 1,
+		problems := ValidateTickets(ticketSlice)
+
+		assert.Empty(t, problems)
+	})
+
+	t.Run("Validate ticket slice with injected problems", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		ticketSlice[1].name = " "
+		ticketSlice[2].email = "not-an-email"
+		ticketSlice[3].ticketPrice = -100
+		ticketSlice[4].departureTime = time.Time{}
+		ticketSlice = append(ticketSlice, ticketSlice[0], ticketSlice[0])
+
+		expectedProblems := []string{
+			"ticket 2: empty name",
+			`ticket 3: invalid email "not-an-email"`,
+			"ticket 4: negative price -100",
+			"ticket 5: zero departure time",
+			"duplicate ticket id: 1",
 		}
 
-		count, err := GetCountByPeriod(ticketSlice)
+		problems := ValidateTickets(ticketSlice)
 
-		assert.Equal(t, expectedCount, count)
-		assert.NoError(t, err)
+		var messages []string
+		for _, problem := range problems {
+			messages = append(messages, problem.Error())
+		}
+		assert.Equal(t, expectedProblems, messages)
 	})
 }
 
-func TestAverageDestination(t *testing.T) {
-	t.Run("Search in empty ticket slice", func(t *testing.T) {
-		var ticketSlice []Ticket
-		expectedAvg := float64(0)
+func TestTicketString(t *testing.T) {
+	t.Run("Format a known ticket", func(t *testing.T) {
+		filename := "./ticket_test.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
 
-		avg, err := AverageDestination(ticketSlice, "China")
+		output := fmt.Sprint(ticketSlice[0])
 
-		assert.Equal(t, expectedAvg, avg)
-		assert.Error(t, err)
+		assert.Equal(t, "Ticket#1 Tait Mc Caughan -> Finland at 17:11 ($785)", output)
 	})
+}
 
-	t.Run("Search in valid ticket slice", func(t *testing.T) {
+func TestExtractTicketDataWithOptions(t *testing.T) {
+	t.Run("Duplicate ids with strict mode disabled", func(t *testing.T) {
+		filename := "./duplicate_id_ticket_test.csv"
+
+		tickets, err := ExtractTicketDataWithOptions(filename, ExtractOptions{})
+
+		assert.Len(t, tickets, 4)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Duplicate ids with strict mode enabled", func(t *testing.T) {
+		filename := "./duplicate_id_ticket_test.csv"
+		opts := ExtractOptions{StrictUniqueIDs: true}
+
+		tickets, err := ExtractTicketDataWithOptions(filename, opts)
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, "duplicate ticket id: 2 at row 4 (first seen at row 2)")
+	})
+
+	t.Run("Valid emails with email validation enabled", func(t *testing.T) {
 		filename := "./ticket_test_2.csv"
-		ticketSlice, _ := ExtractTicketData(filename)
+		opts := ExtractOptions{ValidateEmails: true}
 
-		// The test file contains 2 of 4 registered tickets with destination "China".
-		expectedAvg := 0.50
+		tickets, err := ExtractTicketDataWithOptions(filename, opts)
 
-		avg, err := AverageDestination(ticketSlice, "China")
+		assert.Len(t, tickets, 4)
+		assert.NoError(t, err)
+	})
 
-		assert.Equal(t, expectedAvg, avg)
+	t.Run("Invalid email with email validation enabled", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n"+
+			"2,Padget McKee,not-an-email,China,20:19,537\n")
+		opts := ExtractOptions{ValidateEmails: true}
+
+		tickets, err := ExtractTicketDataWithOptions(filename, opts)
+		assert.Nil(t, tickets)
+		assert.ErrorContains(t, err, `row 2, field 'email': invalid email "not-an-email": `)
+
+		// Without the option the email is accepted as is
+		tickets, err = ExtractTicketDataWithOptions(filename, ExtractOptions{})
+		assert.Equal(t, "not-an-email", tickets[1].email)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Empty email with email validation enabled", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,,Finland,17:11,785\n")
+		opts := ExtractOptions{ValidateEmails: true}
+
+		tickets, err := ExtractTicketDataWithOptions(filename, opts)
+
+		assert.Nil(t, tickets)
+		assert.ErrorContains(t, err, `row 1, field 'email': invalid email "": `)
+	})
+
+	t.Run("Negative price with price validation enabled", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n"+
+			"2,Padget McKee,pmckee1@hexun.com,China,20:19,-100\n")
+		opts := ExtractOptions{ValidatePrices: true}
+
+		tickets, err := ExtractTicketDataWithOptions(filename, opts)
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, "row 2, field 'ticket_price': negative price -100")
+
+		// Without the option the price is accepted as is
+		tickets, err = ExtractTicketDataWithOptions(filename, ExtractOptions{})
+		assert.Equal(t, -100, tickets[1].ticketPrice)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Zero price with price validation enabled", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,0\n")
+		opts := ExtractOptions{ValidatePrices: true}
+
+		tickets, err := ExtractTicketDataWithOptions(filename, opts)
+
+		assert.Len(t, tickets, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Price above the ceiling with price validation enabled", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		opts := ExtractOptions{ValidatePrices: true, MaxPrice: 1000}
+
+		tickets, err := ExtractTicketDataWithOptions(filename, opts)
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, "row 4, field 'ticket_price': price 1238 is greater than 1000")
+	})
+
+	t.Run("Unique ids with strict mode enabled", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		opts := ExtractOptions{StrictUniqueIDs: true}
+
+		tickets, err := ExtractTicketDataWithOptions(filename, opts)
+
+		assert.Len(t, tickets, 4)
 		assert.NoError(t, err)
 	})
 }
+
+func TestExtractTicketDataFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"data/tickets.csv": &fstest.MapFile{
+			Data: []byte("1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n"),
+		},
+		"data/empty.csv": &fstest.MapFile{},
+	}
+
+	t.Run("Extract file from the file system", func(t *testing.T) {
+		expectedData, _ := ExtractTicketData("./ticket_test.csv")
+
+		tickets, err := ExtractTicketDataFS(fsys, "data/tickets.csv")
+
+		assert.Equal(t, expectedData, tickets)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Extract empty file from the file system", func(t *testing.T) {
+		tickets, err := ExtractTicketDataFS(fsys, "data/empty.csv")
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, "empty CSV file")
+	})
+
+	t.Run("Extract missing file from the file system", func(t *testing.T) {
+		tickets, err := ExtractTicketDataFS(fsys, "data/missing.csv")
+
+		assert.Nil(t, tickets)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
+
+func TestExtractTicketDataGzip(t *testing.T) {
+	t.Run("Extract gzip-compressed file", func(t *testing.T) {
+		filename := writeGzipTestFile(t, "./ticket_test_2.csv")
+		expectedData, _ := ExtractTicketData("./ticket_test_2.csv")
+
+		tickets, err := ExtractTicketData(filename)
+
+		assert.Equal(t, expectedData, tickets)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Extract uncompressed file with gzip extension", func(t *testing.T) {
+		content, err := os.ReadFile("./ticket_test_2.csv")
+		assert.NoError(t, err)
+		filename := filepath.Join(t.TempDir(), "tickets.csv.gz")
+		assert.NoError(t, os.WriteFile(filename, content, 0o644))
+
+		tickets, err := ExtractTicketData(filename)
+
+		assert.Nil(t, tickets)
+		assert.ErrorIs(t, err, gzip.ErrHeader)
+	})
+}
+
+func TestExtractTicketDataCRLF(t *testing.T) {
+	t.Run("Extract file with CRLF line endings", func(t *testing.T) {
+		filename := "./crlf_ticket_test.csv"
+		expectedData, _ := ExtractTicketData("./ticket_test_2.csv")
+
+		tickets, err := ExtractTicketData(filename)
+
+		assert.Equal(t, expectedData, tickets)
+		assert.Equal(t, 1238, tickets[3].ticketPrice)
+		assert.NoError(t, err)
+	})
+}
+
+func TestExtractTicketDataWithDelimiter(t *testing.T) {
+	expectedData, _ := ExtractTicketData("./ticket_test_2.csv")
+
+	t.Run("Extract semicolon-delimited file", func(t *testing.T) {
+		filename := writeTestFile(t, "1;Tait Mc Caughan;tmc0@scribd.com;Finland;10:11;785\n"+
+			"2;Padget McKee;pmckee1@hexun.com;China;16:19;537\n"+
+			"3;Yalonda Jermyn;yjermyn2@omniture.com;China;22:11;579\n"+
+			"4;Diannne Pharrow;dpharrow3@icio.us;Mongolia;3:16;1238\n")
+
+		tickets, err := ExtractTicketDataWithDelimiter(filename, ';')
+
+		assert.Equal(t, expectedData, tickets)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Extract tab-delimited file with header", func(t *testing.T) {
+		filename := writeTestFile(t, "id\tname\temail\tdestination\tdeparture_time\tticket_price\n"+
+			"1\tTait Mc Caughan\ttmc0@scribd.com\tFinland\t10:11\t785\n"+
+			"2\tPadget McKee\tpmckee1@hexun.com\tChina\t16:19\t537\n"+
+			"3\tYalonda Jermyn\tyjermyn2@omniture.com\tChina\t22:11\t579\n"+
+			"4\tDiannne Pharrow\tdpharrow3@icio.us\tMongolia\t3:16\t1238\n")
+
+		tickets, err := ExtractTicketDataWithDelimiter(filename, '\t')
+
+		assert.Equal(t, expectedData, tickets)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Extract with an invalid delimiter", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+
+		tickets, err := ExtractTicketDataWithDelimiter(filename, '"')
+
+		assert.Nil(t, tickets)
+		assert.Error(t, err)
+	})
+}
+
+func TestExtractTicketDataWithLayout(t *testing.T) {
+	t.Run("Extract with the default layout", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		expectedData, _ := ExtractTicketData(filename)
+
+		tickets, err := ExtractTicketDataWithLayout(filename, DefaultColumnLayout())
+
+		assert.Equal(t, expectedData, tickets)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Extract with a reordered layout", func(t *testing.T) {
+		filename := writeTestFile(t, "Tait Mc Caughan,1,785,Finland,tmc0@scribd.com,10:11\n"+
+			"Padget McKee,2,537,China,pmckee1@hexun.com,16:19\n"+
+			"Yalonda Jermyn,3,579,China,yjermyn2@omniture.com,22:11\n"+
+			"Diannne Pharrow,4,1238,Mongolia,dpharrow3@icio.us,3:16\n")
+		layout := ColumnLayout{ID: 1, Name: 0, Email: 4, Destination: 3, DepartureTime: 5, TicketPrice: 2}
+		expectedData, _ := ExtractTicketData("./ticket_test_2.csv")
+
+		tickets, err := ExtractTicketDataWithLayout(filename, layout)
+
+		assert.Equal(t, expectedData, tickets)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Extract with an invalid layout", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		layout := DefaultColumnLayout()
+		layout.TicketPrice = layout.ID
+
+		tickets, err := ExtractTicketDataWithLayout(filename, layout)
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, `columns "id" and "ticket_price" share position 0`)
+	})
+}
+
+func TestExtractTicketDataStrict(t *testing.T) {
+	t.Run("Extract file with duplicate ids", func(t *testing.T) {
+		filename := "./duplicate_id_ticket_test.csv"
+
+		tickets, err := ExtractTicketDataStrict(filename)
+		assert.Nil(t, tickets)
+		assert.ErrorContains(t, err, "duplicate ticket id: 2")
+
+		// The default extraction stays lenient
+		tickets, err = ExtractTicketData(filename)
+		assert.Len(t, tickets, 4)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Extract file with unique ids", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+
+		tickets, err := ExtractTicketDataStrict(filename)
+
+		assert.Len(t, tickets, 4)
+		assert.NoError(t, err)
+	})
+}
+
+func TestExtractTicketDataFromReader(t *testing.T) {
+	t.Run("Parse an empty reader", func(t *testing.T) {
+		reader := strings.NewReader("")
+
+		tickets, err := ExtractTicketDataFromReader(reader)
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, "empty CSV file")
+	})
+
+	t.Run("Parse a valid reader", func(t *testing.T) {
+		reader := strings.NewReader("1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n" +
+			"2,Padget McKee,pmckee1@hexun.com,China,20:19,537\n")
+
+		firstTicketTime, _ := time.Parse("15:04", "17:11")
+		secondTicketTime, _ := time.Parse("15:04", "20:19")
+		expectedData := []Ticket{
+			{1, "Tait Mc Caughan", "tmc0@scribd.com", "Finland", firstTicketTime, 785},
+			{2, "Padget McKee", "pmckee1@hexun.com", "China", secondTicketTime, 537},
+		}
+
+		data, err := ExtractTicketDataFromReader(reader)
+
+		assert.Equal(t, expectedData, data)
+		assert.NoError(t, err)
+	})
+}
+
+func TestParseErrorContext(t *testing.T) {
+	t.Run("Invalid price", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n"+
+			"2,Padget McKee,pmckee1@hexun.com,China,20:19,12a\n")
+
+		tickets, err := ExtractTicketData(filename)
+
+		assert.Nil(t, tickets)
+		assert.ErrorContains(t, err, "row 2, field 'ticket_price'")
+		assert.ErrorContains(t, err, `"12a"`)
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+	})
+
+	t.Run("Invalid id", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n"+
+			"2a,Padget McKee,pmckee1@hexun.com,China,20:19,537\n")
+
+		tickets, err := ExtractTicketData(filename)
+
+		assert.Nil(t, tickets)
+		assert.ErrorContains(t, err, "row 2, field 'id'")
+		var numErr *strconv.NumError
+		assert.ErrorAs(t, err, &numErr)
+	})
+
+	t.Run("Invalid departure time", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n"+
+			"2,Padget McKee,pmckee1@hexun.com,China,25:99,537\n"+
+			"3,Yalonda Jermyn,yjermyn2@omniture.com,China,18:11,579\n")
+
+		tickets, err := ExtractTicketData(filename)
+
+		assert.Nil(t, tickets)
+		assert.ErrorContains(t, err, "row 2, field 'departure_time'")
+		var parseErr *time.ParseError
+		assert.ErrorAs(t, err, &parseErr)
+	})
+}
+
+func TestExtractTicketDataLenient(t *testing.T) {
+	t.Run("Extract an empty file", func(t *testing.T) {
+		filename := "./empty_ticket_test.csv"
+
+		tickets, rowErrors, err := ExtractTicketDataLenient(filename)
+
+		assert.Nil(t, tickets)
+		assert.Nil(t, rowErrors)
+		assert.EqualError(t, err, "empty CSV file")
+	})
+
+	t.Run("Extract a valid file", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		expectedData, _ := ExtractTicketData(filename)
+
+		tickets, rowErrors, err := ExtractTicketDataLenient(filename)
+
+		assert.Equal(t, expectedData, tickets)
+		assert.Empty(t, rowErrors)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Extract a file with good and bad rows", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n"+
+			"2,Padget McKee,pmckee1@hexun.com,China,20:19,12a\n"+
+			"3,Yalonda Jermyn,yjermyn2@omniture.com,China,18:11,579\n"+
+			"4,Diannne Pharrow,dpharrow3@icio.us,Mongolia\n"+
+			"5,Bad \"quote,bad@example.com,Peru,10:00,100\n"+
+			"6,Padget McKee,pmckee1@hexun.com,Finland,13:45,400\n")
+
+		tickets, rowErrors, err := ExtractTicketDataLenient(filename)
+		assert.NoError(t, err)
+
+		// The good rows survive
+		assert.Len(t, tickets, 3)
+		for i, id := range []int{1, 3, 6} {
+			assert.Equal(t, id, tickets[i].id)
+		}
+
+		// The bad rows are reported with their line
+		assert.Len(t, rowErrors, 3)
+		for i, line := range []int{2, 4, 5} {
+			assert.Equal(t, line, rowErrors[i].Line)
+		}
+		assert.ErrorContains(t, rowErrors[0], "row 2, field 'ticket_price'")
+		assert.ErrorIs(t, rowErrors[0], strconv.ErrSyntax)
+		assert.EqualError(t, rowErrors[1], "row 4: expected 6 fields, got 4")
+		assert.ErrorIs(t, rowErrors[2], csv.ErrBareQuote)
+	})
+
+	t.Run("Extract gzip-compressed file", func(t *testing.T) {
+		filename := writeGzipTestFile(t, "./ticket_test_2.csv")
+		expectedData, _ := ExtractTicketData("./ticket_test_2.csv")
+
+		tickets, rowErrors, err := ExtractTicketDataLenient(filename)
+
+		assert.Equal(t, expectedData, tickets)
+		assert.Empty(t, rowErrors)
+		assert.NoError(t, err)
+	})
+}
+
+func TestStreamTicketData(t *testing.T) {
+	t.Run("Stream a multi-row file", func(t *testing.T) {
+		file, err := os.Open("./ticket_test_2.csv")
+		assert.NoError(t, err)
+		defer file.Close()
+		expectedData, _ := ExtractTicketData("./ticket_test_2.csv")
+
+		var data []Ticket
+		err = StreamTicketData(file, func(ticket Ticket) error {
+			data = append(data, ticket)
+			return nil
+		})
+
+		assert.Equal(t, expectedData, data)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Stop streaming on a callback error", func(t *testing.T) {
+		file, err := os.Open("./ticket_test_2.csv")
+		assert.NoError(t, err)
+		defer file.Close()
+		callbackErr := errors.New("stop")
+
+		calls := 0
+		err = StreamTicketData(file, func(ticket Ticket) error {
+			calls++
+			if ticket.destination == "China" {
+				return callbackErr
+			}
+			return nil
+		})
+
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, callbackErr, err)
+	})
+
+	t.Run("Stream an invalid row", func(t *testing.T) {
+		reader := strings.NewReader("1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n" +
+			"2,Padget McKee,pmckee1@hexun.com,China,20:19,free\n")
+
+		calls := 0
+		err := StreamTicketData(reader, func(ticket Ticket) error {
+			calls++
+			return nil
+		})
+
+		assert.Equal(t, 1, calls)
+		assert.Error(t, err)
+	})
+}
+
+func TestExtractTicketDataColumns(t *testing.T) {
+	t.Run("Request only destination and price", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		columns := []string{"destination", "ticket_price"}
+
+		expectedData := []Ticket{
+			{destination: "Finland", ticketPrice: 785},
+			{destination: "China", ticketPrice: 537},
+			{destination: "China", ticketPrice: 579},
+			{destination: "Mongolia", ticketPrice: 1238},
+		}
+
+		data, err := ExtractTicketDataColumns(filename, columns)
+
+		assert.Equal(t, expectedData, data)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Skipped columns are not validated", func(t *testing.T) {
+		filename := writeTestFile(t, "1,Tait Mc Caughan,tmc0@scribd.com,Finland,not a time,785\n")
+		columns := []string{"destination", "ticket_price"}
+
+		expectedData := []Ticket{{destination: "Finland", ticketPrice: 785}}
+
+		data, err := ExtractTicketDataColumns(filename, columns)
+
+		assert.Equal(t, expectedData, data)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Request an unknown column", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		columns := []string{"destination", "seat"}
+
+		data, err := ExtractTicketDataColumns(filename, columns)
+
+		assert.Nil(t, data)
+		assert.EqualError(t, err, `unknown column "seat"`)
+	})
+}
+
+func TestGetTotalTicketsByDestination(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		destination := "China"
+		var ticketSlice []Ticket
+		expectedTotalTickets := 0
+
+		totalTickets, err := GetTotalTicketsByDestination(ticketSlice, destination)
+
+		assert.Equal(t, expectedTotalTickets, totalTickets)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test.csv"
+		destination := "Finland"
+		ticketSlice, _ := ExtractTicketData(filename)
+		expectedTotalTickets := 1
+
+		totalTickets, err := GetTotalTicketsByDestination(ticketSlice, destination)
+		assert.Equal(t, expectedTotalTickets, totalTickets)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search in valid ticket slice (0 results)", func(t *testing.T) {
+		filename := "./ticket_test.csv"
+		destination := "The Moon"
+		ticketSlice, _ := ExtractTicketData(filename)
+		expectedTotalTickets := 0
+
+		totalTickets, err := GetTotalTicketsByDestination(ticketSlice, destination)
+		assert.Equal(t, expectedTotalTickets, totalTickets)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetTotalTicketsByDestinationFold(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		totalTickets, err := GetTotalTicketsByDestinationFold(ticketSlice, "China")
+
+		assert.Equal(t, 0, totalTickets)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in ticket slice with mixed cases", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		ticketSlice[0].destination = "china"
+		ticketSlice[3].destination = "CHINA"
+
+		totalTickets, err := GetTotalTicketsByDestinationFold(ticketSlice, "cHiNa")
+		caseSensitiveTotal, _ := GetTotalTicketsByDestination(ticketSlice, "China")
+
+		assert.Equal(t, 4, totalTickets)
+		assert.Equal(t, 2, caseSensitiveTotal)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search in valid ticket slice (0 results)", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		totalTickets, err := GetTotalTicketsByDestinationFold(ticketSlice, "The Moon")
+
+		assert.Equal(t, 0, totalTickets)
+		assert.Error(t, err)
+	})
+}
+
+func TestCheckTimeBetweenLimits(t *testing.T) {
+	t.Run("Lower limit greater than upper limit", func(t *testing.T) {
+		targetTime, _ := time.Parse("15:04", "17:11")
+		lowerLimit, _ := time.Parse("15:04", "18:11")
+		upperLimit, _ := time.Parse("15:04", "16:11")
+
+		result, err := checkTimeBetweenLimits(targetTime, lowerLimit, upperLimit, false, false)
+		assert.False(t, result)
+		assert.Error(t, err)
+	})
+
+	t.Run("Target is between lower and upper limit", func(t *testing.T) {
+		targetTime, _ := time.Parse("15:04", "17:11")
+		lowerLimit, _ := time.Parse("15:04", "16:11")
+		upperLimit, _ := time.Parse("15:04", "18:11")
+
+		result, err := checkTimeBetweenLimits(targetTime, lowerLimit, upperLimit, false, false)
+
+		assert.True(t, result)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Target is before lower limit", func(t *testing.T) {
+		targetTime, _ := time.Parse("15:04", "15:11")
+		lowerLimit, _ := time.Parse("15:04", "16:11")
+		upperLimit, _ := time.Parse("15:04", "18:11")
+
+		result, err := checkTimeBetweenLimits(targetTime, lowerLimit, upperLimit, false, false)
+
+		assert.False(t, result)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Target is after upper limit", func(t *testing.T) {
+		targetTime, _ := time.Parse("15:04", "19:11")
+		lowerLimit, _ := time.Parse("15:04", "16:11")
+		upperLimit, _ := time.Parse("15:04", "18:11")
+
+		result, err := checkTimeBetweenLimits(targetTime, lowerLimit, upperLimit, false, false)
+
+		assert.False(t, result)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Target is on the limits", func(t *testing.T) {
+		lowerLimit, _ := time.Parse("15:04", "16:11")
+		upperLimit, _ := time.Parse("15:04", "18:11")
+
+		for _, inclusivity := range []struct{ includeStart, includeEnd bool }{
+			{false, false},
+			{true, false},
+			{false, true},
+			{true, true},
+		} {
+			onLower, err := checkTimeBetweenLimits(
+				lowerLimit,
+				lowerLimit,
+				upperLimit,
+				inclusivity.includeStart,
+				inclusivity.includeEnd,
+			)
+			assert.Equal(t, inclusivity.includeStart, onLower)
+			assert.NoError(t, err)
+
+			onUpper, err := checkTimeBetweenLimits(
+				upperLimit,
+				lowerLimit,
+				upperLimit,
+				inclusivity.includeStart,
+				inclusivity.includeEnd,
+			)
+			assert.Equal(t, inclusivity.includeEnd, onUpper)
+			assert.NoError(t, err)
+		}
+	})
+}
+
+func TestGetCountByPeriod(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		count, err := GetCountByPeriod(ticketSlice)
+
+		assert.Nil(t, count)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedCount := map[string]int{
+			"morning":       1,
+			"afternoon":     1,
+			"evening":       1,
+			"early_morning": 1,
+		}
+
+		count, err := GetCountByPeriod(ticketSlice)
+
+		assert.Equal(t, expectedCount, count)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Tickets in each documented range", func(t *testing.T) {
+		var ticketSlice []Ticket
+		for i, hour := range []string{"7:30", "12:45", "13:30", "19:45", "20:30", "23:45", "0:30", "6:45"} {
+			departureTime, _ := time.Parse("15:04", hour)
+			ticketSlice = append(ticketSlice, Ticket{id: i + 1, departureTime: departureTime})
+		}
+
+		expectedCount := map[string]int{
+			"morning":       2,
+			"afternoon":     2,
+			"evening":       2,
+			"early_morning": 2,
+		}
+
+		count, err := GetCountByPeriod(ticketSlice)
+
+		assert.Equal(t, expectedCount, count)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetPeriod(t *testing.T) {
+	t.Run("Departures exactly on the period limits", func(t *testing.T) {
+		expectedPeriods := map[string]string{
+			"0:00":  "early_morning",
+			"7:00":  "morning",
+			"13:00": "afternoon",
+			"20:00": "evening",
+			"23:59": "evening",
+		}
+
+		for hour, expectedPeriod := range expectedPeriods {
+			departureTime, _ := time.Parse("15:04", hour)
+
+			period, ok := getPeriod(departureTime)
+
+			assert.Equal(t, expectedPeriod, period, hour)
+			assert.True(t, ok, hour)
+		}
+	})
+}
+
+func TestCheckTimeInPeriod(t *testing.T) {
+	eveningStart, _ := time.Parse("15:04", "20:00")
+	eveningEnd, _ := time.Parse("15:04", "0:00")
+
+	t.Run("Target in a period wrapping past midnight", func(t *testing.T) {
+		beforeMidnight := time.Date(0, time.January, 1, 23, 59, 30, 500000000, time.UTC)
+		afterMidnight, _ := time.Parse("15:04", "0:00")
+		afternoon, _ := time.Parse("15:04", "19:59")
+
+		assert.True(t, checkTimeInPeriod(eveningStart, eveningStart, eveningEnd))
+		assert.True(t, checkTimeInPeriod(beforeMidnight, eveningStart, eveningEnd))
+		assert.False(t, checkTimeInPeriod(afterMidnight, eveningStart, eveningEnd))
+		assert.False(t, checkTimeInPeriod(afternoon, eveningStart, eveningEnd))
+	})
+}
+
+func TestGetCountByPeriodTyped(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		count, err := GetCountByPeriodTyped(ticketSlice)
+
+		assert.Nil(t, count)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedCount := map[Period]int{
+			EarlyMorning: 1,
+			Morning:      1,
+			Afternoon:    2,
+			Evening:      1,
+		}
+
+		count, err := GetCountByPeriodTyped(ticketSlice)
+
+		assert.Equal(t, expectedCount, count)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Typed map covers all the periods", func(t *testing.T) {
+		filename := "./ticket_test.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		countByName, _ := GetCountByPeriod(ticketSlice)
+
+		count, err := GetCountByPeriodTyped(ticketSlice)
+
+		assert.NoError(t, err)
+		assert.Len(t, count, 4)
+		for _, period := range []Period{EarlyMorning, Morning, Afternoon, Evening} {
+			assert.Contains(t, count, period)
+			assert.Equal(t, countByName[period.String()], count[period])
+		}
+	})
+
+	t.Run("Format an unknown period", func(t *testing.T) {
+		assert.Equal(t, "Period(7)", Period(7).String())
+	})
+}
+
+func TestGetCountByCustomPeriods(t *testing.T) {
+	parseRange := func(start, end string) [2]time.Time {
+		startTime, _ := time.Parse("15:04", start)
+		endTime, _ := time.Parse("15:04", end)
+		return [2]time.Time{startTime, endTime}
+	}
+
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+		ranges := map[string][2]time.Time{"day": parseRange("8:00", "18:00")}
+
+		count, err := GetCountByCustomPeriods(ticketSlice, ranges)
+
+		assert.Nil(t, count)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search without ranges", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		count, err := GetCountByCustomPeriods(ticketSlice, nil)
+
+		assert.Nil(t, count)
+		assert.EqualError(t, err, "no ranges specified")
+	})
+
+	t.Run("Search with an empty range", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		ranges := map[string][2]time.Time{"none": parseRange("8:00", "8:00")}
+
+		count, err := GetCountByCustomPeriods(ticketSlice, ranges)
+
+		assert.Nil(t, count)
+		assert.EqualError(t, err, `range "none" must not start and end at the same time: start time must be before end time`)
+		assert.ErrorIs(t, err, ErrInvalidTimeRange)
+	})
+
+	t.Run("Search with overlapping and wrapping ranges", func(t *testing.T) {
+		// Departures: 17:11, 20:19, 8:30, 3:16 and 13:45
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		ranges := map[string][2]time.Time{
+			"day_shift":   parseRange("8:30", "17:11"),
+			"late_shift":  parseRange("13:00", "21:00"),
+			"night_shift": parseRange("20:19", "3:17"),
+			"idle":        parseRange("4:00", "5:00"),
+		}
+
+		expectedCount := map[string]int{
+			"day_shift":   2,
+			"late_shift":  3,
+			"night_shift": 2,
+			"idle":        0,
+		}
+
+		count, err := GetCountByCustomPeriods(ticketSlice, ranges)
+
+		assert.Equal(t, expectedCount, count)
+		assert.NoError(t, err)
+	})
+}
+
+func TestAverageDestination(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+		expectedAvg := float64(0)
+
+		avg, err := AverageDestination(ticketSlice, "China")
+
+		assert.Equal(t, expectedAvg, avg)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// The test file contains 2 of 4 registered tickets with destination "China".
+		expectedAvg := 50.0
+
+		avg, err := AverageDestination(ticketSlice, "China")
+
+		assert.Equal(t, expectedAvg, avg)
+		assert.NoError(t, err)
+	})
+}
+
+func TestHighValueShare(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		share, err := HighValueShare(ticketSlice, 500)
+
+		assert.Equal(t, float64(0), share)
+		assert.Error(t, err)
+	})
+
+	t.Run("Threshold equal to a ticket price", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// The test file prices are 785, 537, 579 and 1238, so the 785 ticket is included.
+		expectedShare := 0.50
+
+		share, err := HighValueShare(ticketSlice, 785)
+
+		assert.Equal(t, expectedShare, share)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Threshold just above a ticket price", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		expectedShare := 0.25
+
+		share, err := HighValueShare(ticketSlice, 786)
+
+		assert.Equal(t, expectedShare, share)
+		assert.NoError(t, err)
+	})
+}
+
+func TestReconcileTotals(t *testing.T) {
+	t.Run("Merged slice matches the parts", func(t *testing.T) {
+		firstPart, _ := ExtractTicketData("./ticket_test.csv")
+		secondPart, _ := ExtractTicketData("./ticket_test_2.csv")
+		merged := append(append([]Ticket{}, firstPart...), secondPart...)
+
+		err := ReconcileTotals([][]Ticket{firstPart, secondPart}, merged, ReconcileOptions{})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Merged slice is missing tickets", func(t *testing.T) {
+		firstPart, _ := ExtractTicketData("./ticket_test.csv")
+		secondPart, _ := ExtractTicketData("./ticket_test_2.csv")
+
+		err := ReconcileTotals([][]Ticket{firstPart, secondPart}, secondPart, ReconcileOptions{})
+
+		assert.EqualError(t, err, "merged tickets mismatch: expected 5 tickets from 2 parts, got 4")
+	})
+
+	opts := ReconcileOptions{Deduplicate: true}
+
+	t.Run("Merged slice matches the distinct tickets", func(t *testing.T) {
+		// Both files contain a ticket with id 1, so the deduplicated merge has 4 tickets
+		firstPart, _ := ExtractTicketData("./ticket_test.csv")
+		secondPart, _ := ExtractTicketData("./ticket_test_2.csv")
+
+		err := ReconcileTotals([][]Ticket{firstPart, secondPart}, secondPart, opts)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Merged slice keeps duplicated tickets", func(t *testing.T) {
+		firstPart, _ := ExtractTicketData("./ticket_test.csv")
+		secondPart, _ := ExtractTicketData("./ticket_test_2.csv")
+		merged := append(append([]Ticket{}, firstPart...), secondPart...)
+
+		err := ReconcileTotals([][]Ticket{firstPart, secondPart}, merged, opts)
+
+		assert.EqualError(t, err, "merged tickets mismatch: expected 4 distinct tickets from 2 parts, got 5")
+	})
+}
+
+func TestAveragePriceByDestinationInWindow(t *testing.T) {
+	start, _ := time.Parse("15:04", "12:00")
+	end, _ := time.Parse("15:04", "23:00")
+
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		avg, err := AveragePriceByDestinationInWindow(ticketSlice, "China", start, end)
+
+		assert.Equal(t, float64(0), avg)
+		assert.EqualError(t, err, "no tickets found")
+	})
+
+	t.Run("Start time after end time", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		avg, err := AveragePriceByDestinationInWindow(ticketSlice, "China", end, start)
+
+		assert.Equal(t, float64(0), avg)
+		assert.EqualError(t, err, "start time must be before end time")
+	})
+
+	t.Run("Destination not found", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		avg, err := AveragePriceByDestinationInWindow(ticketSlice, "The Moon", start, end)
+
+		assert.Equal(t, float64(0), avg)
+		assert.EqualError(t, err, "no tickets found for destination The Moon")
+	})
+
+	t.Run("No tickets of the destination within the window", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		avg, err := AveragePriceByDestinationInWindow(ticketSlice, "Mongolia", start, end)
+
+		assert.Equal(t, float64(0), avg)
+		assert.EqualError(t, err, "no tickets found for destination Mongolia between 12:00 and 23:00")
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// Both tickets to China (16:19 and 22:11) depart within the window.
+		expectedAvg := 558.0
+
+		avg, err := AveragePriceByDestinationInWindow(ticketSlice, "China", start, end)
+
+		assert.Equal(t, expectedAvg, avg)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Window bounds are inclusive", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		windowStart, _ := time.Parse("15:04", "16:19")
+		windowEnd, _ := time.Parse("15:04", "16:19")
+		expectedAvg := 537.0
+
+		avg, err := AveragePriceByDestinationInWindow(ticketSlice, "China", windowStart, windowEnd)
+
+		assert.Equal(t, expectedAvg, avg)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Dated departures are compared by their time of day", func(t *testing.T) {
+		departureTime, _ := time.Parse("2006-01-02 15:04", "2024-03-15 17:11")
+		ticketSlice := []Ticket{{id: 1, destination: "Finland", departureTime: departureTime, ticketPrice: 785}}
+		windowStart, _ := time.Parse("15:04", "17:00")
+		windowEnd, _ := time.Parse("15:04", "18:00")
+		expectedAvg := 785.0
+
+		window, _ := GetTicketsInTimeWindow(ticketSlice, windowStart, windowEnd)
+		avg, err := AveragePriceByDestinationInWindow(ticketSlice, "Finland", windowStart, windowEnd)
+
+		assert.Equal(t, ticketSlice, window)
+		assert.Equal(t, expectedAvg, avg)
+		assert.NoError(t, err)
+	})
+}
+
+func TestToMapByID(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		ticketsByID, err := ToMapByID(ticketSlice)
+
+		assert.Nil(t, ticketsByID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Tickets with unique ids", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		ticketsByID, err := ToMapByID(ticketSlice)
+
+		assert.Len(t, ticketsByID, 4)
+		for _, ticket := range ticketSlice {
+			assert.Equal(t, ticket, ticketsByID[ticket.id])
+		}
+		assert.NoError(t, err)
+	})
+
+	t.Run("Tickets with duplicate ids", func(t *testing.T) {
+		firstPart, _ := ExtractTicketData("./ticket_test.csv")
+		secondPart, _ := ExtractTicketData("./ticket_test_2.csv")
+		ticketSlice := append(append([]Ticket{}, firstPart...), secondPart...)
+
+		ticketsByID, err := ToMapByID(ticketSlice)
+
+		assert.Nil(t, ticketsByID)
+		assert.EqualError(t, err, "duplicate ticket id 1")
+	})
+}
+
+func TestCountByPeriodFromFile(t *testing.T) {
+	t.Run("Open inexistent tickets file", func(t *testing.T) {
+		count, err := CountByPeriodFromFile("./inexistent_file.csv")
+
+		assert.Nil(t, count)
+		assert.Error(t, err)
+	})
+
+	t.Run("Open a empty tickets file", func(t *testing.T) {
+		count, err := CountByPeriodFromFile("./empty_ticket_test.csv")
+
+		assert.Nil(t, count)
+		assert.Error(t, err)
+	})
+
+	t.Run("Same result as the slice based count", func(t *testing.T) {
+		for _, filename := range []string{"./ticket_test_2.csv", "./ticket_test_3.csv", "../../tickets.csv"} {
+			ticketSlice, _ := ExtractTicketData(filename)
+			expectedCount, _ := GetCountByPeriod(ticketSlice)
+
+			count, err := CountByPeriodFromFile(filename)
+
+			assert.Equal(t, expectedCount, count)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("Count gzip-compressed file", func(t *testing.T) {
+		filename := writeGzipTestFile(t, "./ticket_test_3.csv")
+		ticketSlice, _ := ExtractTicketData("./ticket_test_3.csv")
+		expectedCount, _ := GetCountByPeriod(ticketSlice)
+
+		count, err := CountByPeriodFromFile(filename)
+
+		assert.Equal(t, expectedCount, count)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetTicketByID(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		ticket, err := GetTicketByID(ticketSlice, 1)
+
+		assert.Equal(t, Ticket{}, ticket)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search an existing id", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		ticket, err := GetTicketByID(ticketSlice, 3)
+
+		assert.Equal(t, ticketSlice[2], ticket)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search a missing id", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		ticket, err := GetTicketByID(ticketSlice, 42)
+
+		assert.Equal(t, Ticket{}, ticket)
+		assert.EqualError(t, err, "no ticket found with id 42")
+	})
+
+	t.Run("Search a duplicate id", func(t *testing.T) {
+		filename := "./duplicate_id_ticket_test.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// The first ticket with id 2 wins
+		ticket, err := GetTicketByID(ticketSlice, 2)
+
+		assert.Equal(t, "Padget McKee", ticket.name)
+		assert.NoError(t, err)
+	})
+}
+
+func TestBuildIDIndex(t *testing.T) {
+	t.Run("Index empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		index := BuildIDIndex(ticketSlice)
+
+		assert.Empty(t, index)
+	})
+
+	t.Run("Index ticket slice with duplicate ids", func(t *testing.T) {
+		filename := "./duplicate_id_ticket_test.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		index := BuildIDIndex(ticketSlice)
+
+		assert.Len(t, index, 3)
+		assert.Equal(t, ticketSlice[1], index[2])
+		for _, ticket := range ticketSlice[1:] {
+			expectedTicket, _ := GetTicketByID(ticketSlice, ticket.id)
+			assert.Equal(t, expectedTicket, index[ticket.id])
+		}
+	})
+}
+
+func TestPeriodBreakdown(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		breakdown, err := PeriodBreakdown(ticketSlice)
+
+		assert.Nil(t, breakdown)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedBreakdown := map[string]PeriodStat{
+			"morning":       {Count: 1, Percent: 25},
+			"afternoon":     {Count: 1, Percent: 25},
+			"evening":       {Count: 1, Percent: 25},
+			"early_morning": {Count: 1, Percent: 25},
+		}
+
+		breakdown, err := PeriodBreakdown(ticketSlice)
+
+		assert.Equal(t, expectedBreakdown, breakdown)
+		assert.NoError(t, err)
+	})
+}
+
+func TestFilterByEmailDomain(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		filtered, err := FilterByEmailDomain(ticketSlice, "scribd.com")
+
+		assert.Nil(t, filtered)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in ticket slice with mixed domains", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// Tickets 1, 3 and 4 belong to scribd.com, written with different cases.
+		expectedData := []Ticket{ticketSlice[0], ticketSlice[2], ticketSlice[3]}
+
+		filtered, err := FilterByEmailDomain(ticketSlice, "SCRIBD.com")
+
+		assert.Equal(t, expectedData, filtered)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search in ticket slice (0 results)", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		filtered, err := FilterByEmailDomain(ticketSlice, "google.com")
+
+		assert.NotNil(t, filtered)
+		assert.Empty(t, filtered)
+		assert.NoError(t, err)
+	})
+}
+
+func TestIncompleteTickets(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		incomplete, err := IncompleteTickets(ticketSlice)
+
+		assert.Nil(t, incomplete)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in ticket slice with incomplete tickets", func(t *testing.T) {
+		departureTime, _ := time.Parse("15:04", "10:00")
+		ticketSlice := []Ticket{
+			{1, "Tait Mc Caughan", "tmc0@scribd.com", "Finland", departureTime, 785},
+			{2, "", "pmckee1@hexun.com", "China", departureTime, 537},
+			{3, "Yalonda Jermyn", "", "China", departureTime, 579},
+			{4, "Diannne Pharrow", "dpharrow3@icio.us", "", departureTime, 1238},
+			{5, "Saree Nobes", "snobes4@google.com.au", "Czech Republic", time.Time{}, 1398},
+			{6, "Tait Mc Caughan", "tmc0@scribd.com", "Finland", departureTime, -1},
+			{7, "Padget McKee", "pmckee1@hexun.com", "China", departureTime, 0},
+		}
+		expectedIncomplete := ticketSlice[1:6]
+
+		incomplete, err := IncompleteTickets(ticketSlice)
+
+		assert.Equal(t, expectedIncomplete, incomplete)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCompletenessScore(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		score, err := CompletenessScore(ticketSlice)
+
+		assert.Equal(t, float64(0), score)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in complete ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		score, err := CompletenessScore(ticketSlice)
+
+		assert.Equal(t, 1.0, score)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search in ticket slice with an incomplete ticket", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		ticketSlice[0].email = ""
+
+		score, err := CompletenessScore(ticketSlice)
+
+		assert.Equal(t, 0.75, score)
+		assert.NoError(t, err)
+	})
+}
+
+func TestUniqueTripCount(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		count, err := UniqueTripCount(ticketSlice)
+
+		assert.Equal(t, 0, count)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// A repeated trip (same email with different case and same destination) is counted once
+		repeatedTrip := ticketSlice[0]
+		repeatedTrip.id = 6
+		repeatedTrip.email = "TMC0@SCRIBD.COM"
+		ticketSlice = append(ticketSlice, repeatedTrip)
+		expectedCount := 5
+
+		count, err := UniqueTripCount(ticketSlice)
+
+		assert.Equal(t, expectedCount, count)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetCountByTimeBin(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		count, err := GetCountByTimeBin(ticketSlice, 30)
+
+		assert.Nil(t, count)
+		assert.Error(t, err)
+	})
+
+	t.Run("Bin size that does not divide the day", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		for _, binMinutes := range []int{-30, 0, 7} {
+			count, err := GetCountByTimeBin(ticketSlice, binMinutes)
+
+			assert.Nil(t, count)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("Search in valid ticket slice with 30 minutes bins", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		count, err := GetCountByTimeBin(ticketSlice, 30)
+
+		assert.Len(t, count, 48)
+		assert.Equal(t, 1, count["10:00"])
+		assert.Equal(t, 1, count["16:00"])
+		assert.Equal(t, 1, count["22:00"])
+		assert.Equal(t, 1, count["03:00"])
+		assert.Equal(t, 0, count["03:30"])
+		assert.NoError(t, err)
+	})
+}
+
+func TestCoBookedDestinations(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		coBooked, err := CoBookedDestinations(ticketSlice)
+
+		assert.Nil(t, coBooked)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// Two passengers booked both Finland and China, nobody else went to Mongolia.
+		expectedCoBooked := map[string][]string{
+			"Finland":  {"China"},
+			"China":    {"Finland"},
+			"Mongolia": {},
+		}
+
+		coBooked, err := CoBookedDestinations(ticketSlice)
+
+		assert.Equal(t, expectedCoBooked, coBooked)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRevenuePerPassenger(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		revenue, err := RevenuePerPassenger(ticketSlice)
+
+		assert.Equal(t, float64(0), revenue)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// The test file has a total revenue of 3560 paid by 3 distinct passengers.
+		expectedRevenue := 3560.0 / 3
+
+		revenue, err := RevenuePerPassenger(ticketSlice)
+
+		assert.Equal(t, expectedRevenue, revenue)
+		assert.NoError(t, err)
+	})
+}
+
+func TestValidateSchema(t *testing.T) {
+	validRow := "1,Tait Mc Caughan,tmc0@scribd.com,Finland,17:11,785\n"
+
+	t.Run("Validate inexistent tickets file", func(t *testing.T) {
+		err := ValidateSchema("./inexistent_file.csv")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Validate empty tickets file", func(t *testing.T) {
+		err := ValidateSchema("./empty_ticket_test.csv")
+
+		assert.EqualError(t, err, "empty CSV file")
+	})
+
+	t.Run("Validate a valid tickets file", func(t *testing.T) {
+		err := ValidateSchema("./ticket_test_2.csv")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Rows with dated departure times", func(t *testing.T) {
+		filename := writeTestFile(t, validRow+
+			"2,Padget McKee,pmckee1@hexun.com,China,2024-03-15 20:19,537\n"+
+			"3,Yalonda Jermyn,yjermyn2@omniture.com,China,2024-03-15T22:11:00Z,579\n")
+
+		err := ValidateSchema(filename)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("File with a header and reordered columns", func(t *testing.T) {
+		filename := writeTestFile(t, "ticket_price,departure_time,destination,email,name,id\n"+
+			"785,17:11,Finland,tmc0@scribd.com,Tait Mc Caughan,1\n"+
+			"537,20:19,China,pmckee1@hexun.com,Padget McKee,2\n")
+
+		err := ValidateSchema(filename)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Violation in a file with reordered columns", func(t *testing.T) {
+		filename := writeTestFile(t, "ticket_price,departure_time,destination,email,name,id\n"+
+			"785,17:11,Finland,tmc0@scribd.com,Tait Mc Caughan,1\n"+
+			"537,20:19,China,pmckee1.hexun.com,Padget McKee,2\n")
+
+		err := ValidateSchema(filename)
+
+		assert.EqualError(t, err, `line 3: email "pmckee1.hexun.com" does not contain an @`)
+	})
+
+	t.Run("Row with missing fields", func(t *testing.T) {
+		filename := writeTestFile(t, validRow+"2,Padget McKee,pmckee1@hexun.com,China\n")
+
+		err := ValidateSchema(filename)
+
+		assert.EqualError(t, err, "line 2: expected 6 fields, got 4")
+	})
+
+	t.Run("Non-integer id", func(t *testing.T) {
+		filename := writeTestFile(t, validRow+"two,Padget McKee,pmckee1@hexun.com,China,20:19,537\n")
+
+		err := ValidateSchema(filename)
+
+		assert.EqualError(t, err, `line 2: id "two" is not an integer`)
+	})
+
+	t.Run("Email without @", func(t *testing.T) {
+		filename := writeTestFile(t, validRow+"2,Padget McKee,pmckee1.hexun.com,China,20:19,537\n")
+
+		err := ValidateSchema(filename)
+
+		assert.EqualError(t, err, `line 2: email "pmckee1.hexun.com" does not contain an @`)
+	})
+
+	t.Run("Invalid departure time", func(t *testing.T) {
+		filename := writeTestFile(t, validRow+"2,Padget McKee,pmckee1@hexun.com,China,25:19,537\n")
+
+		err := ValidateSchema(filename)
+
+		assert.EqualError(t, err, `line 2: departure_time "25:19" is not a valid time`)
+	})
+
+	t.Run("Non-integer ticket price", func(t *testing.T) {
+		filename := writeTestFile(t, validRow+"2,Padget McKee,pmckee1@hexun.com,China,20:19,5.37\n")
+
+		err := ValidateSchema(filename)
+
+		assert.EqualError(t, err, `line 2: ticket_price "5.37" is not an integer`)
+	})
+}
+
+func TestEarlyMorningShare(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		share, err := EarlyMorningShare(ticketSlice)
+
+		assert.Equal(t, float64(0), share)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// Only 1 of the 5 tickets of the test file departs in the early morning (3:16).
+		expectedShare := 0.20
+
+		share, err := EarlyMorningShare(ticketSlice)
+
+		assert.Equal(t, expectedShare, share)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDestinationStats(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		stats, err := DestinationStats(ticketSlice)
+
+		assert.Nil(t, stats)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// A second ticket of the same passenger to Mongolia must not change its unique passengers
+		repeatedTicket := ticketSlice[3]
+		repeatedTicket.id = 6
+		repeatedTicket.ticketPrice = 762
+		ticketSlice = append(ticketSlice, repeatedTicket)
+
+		expectedStats := map[string]DestStat{
+			"Finland":  {Count: 2, TotalRevenue: 1185, AveragePrice: 592.5, UniquePassengers: 2},
+			"China":    {Count: 2, TotalRevenue: 1137, AveragePrice: 568.5, UniquePassengers: 2},
+			"Mongolia": {Count: 2, TotalRevenue: 2000, AveragePrice: 1000, UniquePassengers: 1},
+		}
+
+		stats, err := DestinationStats(ticketSlice)
+
+		assert.Equal(t, expectedStats, stats)
+		assert.NoError(t, err)
+	})
+}
+
+func TestNextDeparture(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+		now, _ := time.Parse("15:04", "12:00")
+
+		ticket, wait, err := NextDeparture(ticketSlice, now)
+
+		assert.Equal(t, Ticket{}, ticket)
+		assert.Equal(t, time.Duration(0), wait)
+		assert.Error(t, err)
+	})
+
+	t.Run("Departure later in the same day", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		now, _ := time.Parse("15:04", "12:00")
+
+		ticket, wait, err := NextDeparture(ticketSlice, now)
+
+		assert.Equal(t, ticketSlice[1], ticket)
+		assert.Equal(t, 4*time.Hour+19*time.Minute, wait)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Departure exactly at the current time", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		now, _ := time.Parse("15:04", "22:11")
+
+		ticket, wait, err := NextDeparture(ticketSlice, now)
+
+		assert.Equal(t, ticketSlice[2], ticket)
+		assert.Equal(t, time.Duration(0), wait)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Departure after midnight", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		now, _ := time.Parse("15:04", "23:30")
+
+		// The next departure is 3:16 of the following day
+		ticket, wait, err := NextDeparture(ticketSlice, now)
+
+		assert.Equal(t, ticketSlice[3], ticket)
+		assert.Equal(t, 3*time.Hour+46*time.Minute, wait)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Current time with a date", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		now := time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+		ticket, wait, err := NextDeparture(ticketSlice, now)
+
+		assert.Equal(t, ticketSlice[3], ticket)
+		assert.Equal(t, 3*time.Hour+16*time.Minute, wait)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPeriodFillRate(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		fillRate, err := PeriodFillRate(ticketSlice)
+
+		assert.Nil(t, fillRate)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// The busiest period of the test file is the afternoon, with 2 tickets.
+		expectedFillRate := map[string]float64{
+			"morning":       0.5,
+			"afternoon":     1,
+			"evening":       0.5,
+			"early_morning": 0.5,
+		}
+
+		fillRate, err := PeriodFillRate(ticketSlice)
+
+		assert.Equal(t, expectedFillRate, fillRate)
+		assert.NoError(t, err)
+	})
+}
+
+func TestVolumeChange(t *testing.T) {
+	t.Run("Empty old dataset", func(t *testing.T) {
+		var oldData []Ticket
+		newData, _ := ExtractTicketData("./ticket_test_2.csv")
+
+		change, err := VolumeChange(oldData, newData)
+
+		assert.Equal(t, float64(0), change)
+		assert.EqualError(t, err, "no tickets found in the old dataset")
+		assert.ErrorIs(t, err, ErrEmptyData)
+	})
+
+	t.Run("Increase in ticket volume", func(t *testing.T) {
+		ticketSlice, _ := ExtractTicketData("./ticket_test_2.csv")
+		expectedChange := 50.0
+
+		change, err := VolumeChange(ticketSlice[:2], ticketSlice[:3])
+
+		assert.Equal(t, expectedChange, change)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Decrease in ticket volume", func(t *testing.T) {
+		ticketSlice, _ := ExtractTicketData("./ticket_test_2.csv")
+		expectedChange := -75.0
+
+		change, err := VolumeChange(ticketSlice, ticketSlice[:1])
+
+		assert.Equal(t, expectedChange, change)
+		assert.NoError(t, err)
+	})
+}
+
+func TestConcurrentDepartures(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		concurrent, err := ConcurrentDepartures(ticketSlice)
+
+		assert.Nil(t, concurrent)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in ticket slice without concurrent departures", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		concurrent, err := ConcurrentDepartures(ticketSlice)
+
+		assert.Empty(t, concurrent)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search in ticket slice with two tickets sharing a slot", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// Same destination and time as the second ticket, but a different passenger
+		sharedSlot := ticketSlice[1]
+		sharedSlot.id = 5
+		sharedSlot.name = "Saree Nobes"
+		ticketSlice = append(ticketSlice, sharedSlot)
+
+		expectedConcurrent := map[string][]Ticket{
+			"China@16:19": {ticketSlice[1], sharedSlot},
+		}
+
+		concurrent, err := ConcurrentDepartures(ticketSlice)
+
+		assert.Equal(t, expectedConcurrent, concurrent)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search in ticket slice with the same time on different days", func(t *testing.T) {
+		firstDay, _ := time.Parse("2006-01-02 15:04", "2024-03-15 17:11")
+		secondDay, _ := time.Parse("2006-01-02 15:04", "2024-03-16 17:11")
+		ticketSlice := []Ticket{
+			{id: 1, destination: "China", departureTime: firstDay},
+			{id: 2, destination: "China", departureTime: secondDay},
+			{id: 3, destination: "China", departureTime: secondDay},
+		}
+
+		expectedConcurrent := map[string][]Ticket{
+			"China@2024-03-16T17:11:00Z": {ticketSlice[1], ticketSlice[2]},
+		}
+
+		concurrent, err := ConcurrentDepartures(ticketSlice)
+
+		assert.Equal(t, expectedConcurrent, concurrent)
+		assert.NoError(t, err)
+	})
+}
+
+func TestAveragePriceByPeriodAndDestination(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		avg, err := AveragePriceByPeriodAndDestination(ticketSlice)
+
+		assert.Nil(t, avg)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedAvg := map[string]map[string]float64{
+			"morning":       {"China": 600},
+			"afternoon":     {"Finland": 592.5},
+			"evening":       {"China": 537},
+			"early_morning": {"Mongolia": 1238},
+		}
+
+		avg, err := AveragePriceByPeriodAndDestination(ticketSlice)
+
+		assert.Equal(t, expectedAvg, avg)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDestinationsForRevenueShare(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		count, destinations, err := DestinationsForRevenueShare(ticketSlice, 0.8)
+
+		assert.Equal(t, 0, count)
+		assert.Nil(t, destinations)
+		assert.Error(t, err)
+	})
+
+	t.Run("Target share out of range", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		for _, targetShare := range []float64{0, -0.5, 1.5} {
+			count, destinations, err := DestinationsForRevenueShare(ticketSlice, targetShare)
+
+			assert.Equal(t, 0, count)
+			assert.Nil(t, destinations)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// Revenue of the test file: Mongolia 1238, Finland 1185 and China 1137 (total 3560).
+		// Mongolia alone is 34.8% of the revenue, adding Finland reaches 68.1%.
+		expectedDestinations := []string{"Mongolia", "Finland"}
+
+		count, destinations, err := DestinationsForRevenueShare(ticketSlice, 0.5)
+
+		assert.Equal(t, 2, count)
+		assert.Equal(t, expectedDestinations, destinations)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Target share of the whole revenue", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		expectedDestinations := []string{"Mongolia", "Finland", "China"}
+
+		count, destinations, err := DestinationsForRevenueShare(ticketSlice, 1)
+
+		assert.Equal(t, 3, count)
+		assert.Equal(t, expectedDestinations, destinations)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRemoveImplausibleTimes(t *testing.T) {
+	earliest, _ := time.Parse("15:04", "05:00")
+	latest, _ := time.Parse("15:04", "23:00")
+
+	t.Run("Earliest time after latest time", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		filtered, removed, err := RemoveImplausibleTimes(ticketSlice, latest, earliest)
+
+		assert.Nil(t, filtered)
+		assert.Equal(t, 0, removed)
+		assert.Error(t, err)
+	})
+
+	t.Run("Remove an early morning departure", func(t *testing.T) {
+		departureTime, _ := time.Parse("15:04", "03:00")
+		ticketSlice := []Ticket{
+			{1, "Tait Mc Caughan", "tmc0@scribd.com", "Finland", departureTime, 785},
+			{2, "Padget McKee", "pmckee1@hexun.com", "China", earliest, 537},
+			{3, "Yalonda Jermyn", "yjermyn2@omniture.com", "China", latest, 579},
+		}
+		expectedData := ticketSlice[1:]
+
+		filtered, removed, err := RemoveImplausibleTimes(ticketSlice, earliest, latest)
+
+		assert.Equal(t, expectedData, filtered)
+		assert.Equal(t, 1, removed)
+		assert.Len(t, ticketSlice, 3)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPriceHourCorrelation(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		correlation, err := PriceHourCorrelation(ticketSlice)
+
+		assert.Equal(t, float64(0), correlation)
+		assert.Error(t, err)
+	})
+
+	t.Run("Tickets with zero variance", func(t *testing.T) {
+		filename := "./ticket_test.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		correlation, err := PriceHourCorrelation(ticketSlice)
+
+		assert.Equal(t, float64(0), correlation)
+		assert.Error(t, err)
+	})
+
+	t.Run("Perfectly correlated tickets", func(t *testing.T) {
+		var ticketSlice []Ticket
+		for i, hour := range []string{"06:00", "09:30", "14:00", "21:15"} {
+			departureTime, _ := time.Parse("15:04", hour)
+			price := 100 + 10*(departureTime.Hour()*60+departureTime.Minute())/15
+			ticketSlice = append(ticketSlice, Ticket{id: i + 1, departureTime: departureTime, ticketPrice: price})
+		}
+
+		correlation, err := PriceHourCorrelation(ticketSlice)
+
+		assert.InDelta(t, 1.0, correlation, 1e-9)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Perfectly inversely correlated tickets", func(t *testing.T) {
+		var ticketSlice []Ticket
+		for i, hour := range []string{"06:00", "09:30", "14:00", "21:15"} {
+			departureTime, _ := time.Parse("15:04", hour)
+			price := 1000 - 10*(departureTime.Hour()*60+departureTime.Minute())/15
+			ticketSlice = append(ticketSlice, Ticket{id: i + 1, departureTime: departureTime, ticketPrice: price})
+		}
+
+		correlation, err := PriceHourCorrelation(ticketSlice)
+
+		assert.InDelta(t, -1.0, correlation, 1e-9)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetCountByWeekday(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		count, err := GetCountByWeekday(ticketSlice)
+
+		assert.Nil(t, count)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in ticket slice without dates", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		count, err := GetCountByWeekday(ticketSlice)
+
+		assert.Nil(t, count)
+		assert.EqualError(t, err, "ticket 1 has no departure date")
+	})
+
+	t.Run("Search in ticket slice with dates", func(t *testing.T) {
+		// March 13, 2023 is a Monday and March 15, 2023 is a Wednesday.
+		ticketSlice := []Ticket{
+			{id: 1, departureTime: time.Date(2023, time.March, 13, 17, 11, 0, 0, time.UTC)},
+			{id: 2, departureTime: time.Date(2023, time.March, 15, 20, 19, 0, 0, time.UTC)},
+			{id: 3, departureTime: time.Date(2023, time.March, 15, 8, 30, 0, 0, time.UTC)},
+		}
+		expectedCount := map[time.Weekday]int{
+			time.Monday:    1,
+			time.Wednesday: 2,
+		}
+
+		count, err := GetCountByWeekday(ticketSlice)
+
+		assert.Equal(t, expectedCount, count)
+		assert.NoError(t, err)
+	})
+}
+
+func TestBreakEvenTickets(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		needed, covered, err := BreakEvenTickets(ticketSlice, "China", 1000)
+
+		assert.Equal(t, 0, needed)
+		assert.False(t, covered)
+		assert.Error(t, err)
+	})
+
+	t.Run("Destination not found", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		needed, covered, err := BreakEvenTickets(ticketSlice, "The Moon", 1000)
+
+		assert.Equal(t, 0, needed)
+		assert.False(t, covered)
+		assert.EqualError(t, err, "no tickets found for destination The Moon")
+	})
+
+	t.Run("Negative fixed cost", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		needed, covered, err := BreakEvenTickets(ticketSlice, "China", -1)
+
+		assert.Equal(t, 0, needed)
+		assert.False(t, covered)
+		assert.Error(t, err)
+	})
+
+	t.Run("Fixed cost covered by current bookings", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// China has 2 tickets with an average price of 568.5, so 1000 needs 2 tickets.
+		needed, covered, err := BreakEvenTickets(ticketSlice, "China", 1000)
+
+		assert.Equal(t, 2, needed)
+		assert.True(t, covered)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fixed cost not covered by current bookings", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		needed, covered, err := BreakEvenTickets(ticketSlice, "China", 2000)
+
+		assert.Equal(t, 4, needed)
+		assert.False(t, covered)
+		assert.NoError(t, err)
+	})
+}
+
+func TestLongestDepartureGap(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		gap, start, end, err := LongestDepartureGap(ticketSlice)
+
+		assert.Equal(t, time.Duration(0), gap)
+		assert.True(t, start.IsZero())
+		assert.True(t, end.IsZero())
+		assert.ErrorIs(t, err, ErrEmptyData)
+	})
+
+	t.Run("Search in ticket slice with a single ticket", func(t *testing.T) {
+		filename := "./ticket_test.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		gap, _, _, err := LongestDepartureGap(ticketSlice)
+
+		assert.Equal(t, time.Duration(0), gap)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		originalSlice := append([]Ticket{}, ticketSlice...)
+
+		// Sorted departures are 3:16, 10:11, 16:19 and 22:11, so the longest gap is the first one.
+		expectedStart, _ := time.Parse("15:04", "3:16")
+		expectedEnd, _ := time.Parse("15:04", "10:11")
+
+		gap, start, end, err := LongestDepartureGap(ticketSlice)
+
+		assert.Equal(t, 6*time.Hour+55*time.Minute, gap)
+		assert.Equal(t, expectedStart, start)
+		assert.Equal(t, expectedEnd, end)
+		assert.Equal(t, originalSlice, ticketSlice)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDestinationPriceIndex(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		priceIndex, err := DestinationPriceIndex(ticketSlice)
+
+		assert.Nil(t, priceIndex)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// The overall average price of the test file is 3560 / 5 = 712.
+		expectedIndex := map[string]float64{
+			"Finland":  592.5 / 712,
+			"China":    568.5 / 712,
+			"Mongolia": 1238.0 / 712,
+		}
+
+		priceIndex, err := DestinationPriceIndex(ticketSlice)
+
+		assert.Len(t, priceIndex, len(expectedIndex))
+		for destination, index := range expectedIndex {
+			assert.InDelta(t, index, priceIndex[destination], 1e-9)
+		}
+		assert.NoError(t, err)
+	})
+}
+
+func TestDomesticInternationalRevenue(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		domestic, international, err := DomesticInternationalRevenue(ticketSlice, "China")
+
+		assert.Equal(t, 0, domestic)
+		assert.Equal(t, 0, international)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in ticket slice with mixed destinations", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// China: 537 + 579, others: 785 (Finland) + 1238 (Mongolia).
+		domestic, international, err := DomesticInternationalRevenue(ticketSlice, "China")
+
+		assert.Equal(t, 1116, domestic)
+		assert.Equal(t, 2023, international)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetTotalRevenue(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		revenue, err := GetTotalRevenue(ticketSlice)
+
+		assert.Equal(t, 0, revenue)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// 785 + 537 + 579 + 1238
+		expectedRevenue := 3139
+
+		revenue, err := GetTotalRevenue(ticketSlice)
+
+		assert.Equal(t, expectedRevenue, revenue)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetRevenueByDestination(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		revenue, err := GetRevenueByDestination(ticketSlice)
+
+		assert.Nil(t, revenue)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedRevenue := map[string]int{
+			"Finland":  785 + 400,
+			"China":    537 + 600,
+			"Mongolia": 1238,
+		}
+
+		revenue, err := GetRevenueByDestination(ticketSlice)
+
+		assert.Equal(t, expectedRevenue, revenue)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetAveragePrice(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		avg, err := GetAveragePrice(ticketSlice)
+
+		assert.Equal(t, float64(0), avg)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in ticket slice with a non-integer average", func(t *testing.T) {
+		ticketSlice := []Ticket{
+			{id: 1, ticketPrice: 100},
+			{id: 2, ticketPrice: 101},
+		}
+		expectedAvg := 100.5
+
+		avg, err := GetAveragePrice(ticketSlice)
+
+		assert.Equal(t, expectedAvg, avg)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetMostPopularDestination(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		destination, count, err := GetMostPopularDestination(ticketSlice)
+
+		assert.Equal(t, "", destination)
+		assert.Equal(t, 0, count)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in ticket slice with a clear winner", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		destination, count, err := GetMostPopularDestination(ticketSlice)
+
+		assert.Equal(t, "China", destination)
+		assert.Equal(t, 2, count)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search in ticket slice with a tie", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// Finland and China have 2 tickets each, China wins alphabetically.
+		destination, count, err := GetMostPopularDestination(ticketSlice)
+
+		assert.Equal(t, "China", destination)
+		assert.Equal(t, 2, count)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetTopNDestinations(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		top, err := GetTopNDestinations(ticketSlice, 3)
+
+		assert.Nil(t, top)
+		assert.Error(t, err)
+	})
+
+	t.Run("Non-positive n", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		for _, n := range []int{0, -1} {
+			top, err := GetTopNDestinations(ticketSlice, n)
+
+			assert.Nil(t, top)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("Tie-break ordering", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedTop := []DestinationCount{
+			{Destination: "China", Count: 2},
+			{Destination: "Finland", Count: 2},
+		}
+
+		top, err := GetTopNDestinations(ticketSlice, 2)
+
+		assert.Equal(t, expectedTop, top)
+		assert.NoError(t, err)
+	})
+
+	t.Run("n larger than the distinct destinations", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedTop := []DestinationCount{
+			{Destination: "China", Count: 2},
+			{Destination: "Finland", Count: 2},
+			{Destination: "Mongolia", Count: 1},
+		}
+
+		top, err := GetTopNDestinations(ticketSlice, 10)
+
+		assert.Equal(t, expectedTop, top)
+		assert.NoError(t, err)
+	})
+}
+
+func TestFilterByPriceRange(t *testing.T) {
+	t.Run("Inverted range", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		filtered, err := FilterByPriceRange(ticketSlice, 800, 500)
+
+		assert.Nil(t, filtered)
+		assert.Error(t, err)
+	})
+
+	t.Run("Inclusive boundaries", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// Prices 537, 579 and 785 are within the range, 1238 is not.
+		expectedData := []Ticket{ticketSlice[0], ticketSlice[1], ticketSlice[2]}
+
+		filtered, err := FilterByPriceRange(ticketSlice, 537, 785)
+
+		assert.Equal(t, expectedData, filtered)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Range without tickets", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		filtered, err := FilterByPriceRange(ticketSlice, 1, 100)
+
+		assert.NotNil(t, filtered)
+		assert.Empty(t, filtered)
+		assert.NoError(t, err)
+	})
+}
+
+func TestFilterByDestination(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		tickets, err := FilterByDestination(ticketSlice, "China")
+
+		assert.Nil(t, tickets)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		expectedData := []Ticket{ticketSlice[1], ticketSlice[2]}
+
+		tickets, err := FilterByDestination(ticketSlice, "China")
+
+		assert.Equal(t, expectedData, tickets)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search in valid ticket slice (0 results)", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		tickets, err := FilterByDestination(ticketSlice, "The Moon")
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, "no tickets found for destination The Moon")
+	})
+}
+
+func TestGetCheapestTicket(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		ticket, err := GetCheapestTicket(ticketSlice)
+
+		assert.Equal(t, Ticket{}, ticket)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search a unique cheapest ticket", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		ticket, err := GetCheapestTicket(ticketSlice)
+
+		assert.Equal(t, ticketSlice[1], ticket)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Tie resolved by the lowest id", func(t *testing.T) {
+		ticketSlice := []Ticket{
+			{id: 7, ticketPrice: 300},
+			{id: 3, ticketPrice: 100},
+			{id: 5, ticketPrice: 100},
+		}
+
+		ticket, err := GetCheapestTicket(ticketSlice)
+
+		assert.Equal(t, 3, ticket.id)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetMostExpensiveTicket(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		ticket, err := GetMostExpensiveTicket(ticketSlice)
+
+		assert.Equal(t, Ticket{}, ticket)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search a unique most expensive ticket", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		ticket, err := GetMostExpensiveTicket(ticketSlice)
+
+		assert.Equal(t, ticketSlice[3], ticket)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Tie resolved by the lowest id", func(t *testing.T) {
+		ticketSlice := []Ticket{
+			{id: 7, ticketPrice: 900},
+			{id: 3, ticketPrice: 100},
+			{id: 5, ticketPrice: 900},
+		}
+
+		ticket, err := GetMostExpensiveTicket(ticketSlice)
+
+		assert.Equal(t, 5, ticket.id)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetMedianPrice(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		median, err := GetMedianPrice(ticketSlice)
+
+		assert.Equal(t, 0.0, median)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in ticket slice with an odd count", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// Prices: 785, 537, 600, 1238 and 400
+		median, err := GetMedianPrice(ticketSlice)
+
+		assert.Equal(t, 600.0, median)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search in ticket slice with an even count", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		originalSlice := make([]Ticket, len(ticketSlice))
+		copy(originalSlice, ticketSlice)
+
+		// Prices: 785, 537, 579 and 1238
+		median, err := GetMedianPrice(ticketSlice)
+
+		assert.Equal(t, 682.0, median)
+		assert.Equal(t, originalSlice, ticketSlice)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetPriceStandardDeviation(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		deviation, err := GetPriceStandardDeviation(ticketSlice)
+
+		assert.Equal(t, 0.0, deviation)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// Prices: 785, 537, 579 and 1238 (mean 784.75)
+		deviation, err := GetPriceStandardDeviation(ticketSlice)
+
+		assert.InDelta(t, 278.0057, deviation, 0.0001)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search in ticket slice with equal prices", func(t *testing.T) {
+		filename := "./ticket_test.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		deviation, err := GetPriceStandardDeviation(ticketSlice)
+
+		assert.Equal(t, 0.0, deviation)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetUniqueDestinations(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		destinations, err := GetUniqueDestinations(ticketSlice)
+
+		assert.Nil(t, destinations)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in ticket slice with repeated destinations", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedDestinations := []string{"China", "Finland", "Mongolia"}
+
+		destinations, err := GetUniqueDestinations(ticketSlice)
+
+		assert.Equal(t, expectedDestinations, destinations)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCountByDestination(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		count, err := CountByDestination(ticketSlice)
+
+		assert.Nil(t, count)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		count, err := CountByDestination(ticketSlice)
+		assert.NoError(t, err)
+
+		// Each count must match the one of GetTotalTicketsByDestination
+		assert.Len(t, count, 3)
+		for destination, total := range count {
+			expectedTotal, err := GetTotalTicketsByDestination(ticketSlice, destination)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedTotal, total)
+		}
+	})
+}
+
+func TestGetTicketsByEmail(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		tickets, err := GetTicketsByEmail(ticketSlice, "tmc0@scribd.com")
+
+		assert.Nil(t, tickets)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search an email with multiple matches", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedTickets := []Ticket{ticketSlice[1], ticketSlice[4]}
+
+		tickets, err := GetTicketsByEmail(ticketSlice, "pmckee1@hexun.com")
+
+		assert.Equal(t, expectedTickets, tickets)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search an email with a different case", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// The file holds the email both in lower case and in mixed case
+		expectedTickets := []Ticket{ticketSlice[0], ticketSlice[2]}
+
+		tickets, err := GetTicketsByEmail(ticketSlice, "TMC0@SCRIBD.COM")
+
+		assert.Equal(t, expectedTickets, tickets)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search an email without matches", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		tickets, err := GetTicketsByEmail(ticketSlice, "nobody@example.com")
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, "no tickets found for email nobody@example.com")
+	})
+}
+
+func TestSearchTicketsByName(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		tickets, err := SearchTicketsByName(ticketSlice, "Tait")
+
+		assert.Nil(t, tickets)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search a partial name", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedTickets := []Ticket{ticketSlice[0], ticketSlice[1]}
+
+		// Matches "Tait Mc Caughan" and "Padget McKee"
+		tickets, err := SearchTicketsByName(ticketSlice, "mc")
+
+		assert.Equal(t, expectedTickets, tickets)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search a full name", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedTickets := []Ticket{ticketSlice[2]}
+
+		tickets, err := SearchTicketsByName(ticketSlice, "YALONDA JERMYN")
+
+		assert.Equal(t, expectedTickets, tickets)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search a name without matches", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		tickets, err := SearchTicketsByName(ticketSlice, "Smith")
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, `no tickets found for name "Smith"`)
+	})
+}
+
+func TestSummarize(t *testing.T) {
+	t.Run("Summarize empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		summary, err := Summarize(ticketSlice)
+
+		assert.Equal(t, Summary{}, summary)
+		assert.Error(t, err)
+	})
+
+	t.Run("Summarize valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// China and Finland have two tickets each, so the first one alphabetically wins
+		expectedSummary := Summary{
+			TotalTickets:           5,
+			TotalRevenue:           3560,
+			AveragePrice:           712,
+			MostPopularDestination: "China",
+		}
+
+		summary, err := Summarize(ticketSlice)
+
+		assert.Equal(t, expectedSummary, summary)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDestinationShare(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		shares, err := DestinationShare(ticketSlice)
+
+		assert.Nil(t, shares)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedShares := map[string]float64{
+			"Finland":  0.4,
+			"China":    0.4,
+			"Mongolia": 0.2,
+		}
+
+		shares, err := DestinationShare(ticketSlice)
+		assert.NoError(t, err)
+
+		total := 0.0
+		assert.Len(t, shares, len(expectedShares))
+		for destination, expectedShare := range expectedShares {
+			assert.InDelta(t, expectedShare, shares[destination], 1e-9)
+			total += shares[destination]
+		}
+		assert.InDelta(t, 1.0, total, 1e-9)
+	})
+}
+
+func TestGetTicketsInTimeWindow(t *testing.T) {
+	t.Run("Search with an inverted window", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		start, _ := time.Parse("15:04", "18:00")
+		end, _ := time.Parse("15:04", "10:00")
+
+		tickets, err := GetTicketsInTimeWindow(ticketSlice, start, end)
+
+		assert.Nil(t, tickets)
+		assert.EqualError(t, err, "start time must be before end time")
+	})
+
+	t.Run("Search with departures exactly on the limits", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		start, _ := time.Parse("15:04", "10:11")
+		end, _ := time.Parse("15:04", "16:19")
+
+		expectedTickets := []Ticket{ticketSlice[0], ticketSlice[1]}
+
+		tickets, err := GetTicketsInTimeWindow(ticketSlice, start, end)
+
+		assert.Equal(t, expectedTickets, tickets)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Search with a window without departures", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+		start, _ := time.Parse("15:04", "4:00")
+		end, _ := time.Parse("15:04", "10:00")
+
+		tickets, err := GetTicketsInTimeWindow(ticketSlice, start, end)
+
+		assert.Empty(t, tickets)
+		assert.NotNil(t, tickets)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetBusiestPeriod(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		period, count, err := GetBusiestPeriod(ticketSlice)
+
+		assert.Equal(t, "", period)
+		assert.Equal(t, 0, count)
+		assert.Error(t, err)
+	})
+
+	t.Run("Search in ticket slice with a clear peak", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		period, count, err := GetBusiestPeriod(ticketSlice)
+
+		assert.Equal(t, "afternoon", period)
+		assert.Equal(t, 2, count)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Tie resolved by the alphabetical period name", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// One ticket in the morning (8:30) and one in the early morning (3:16)
+		period, count, err := GetBusiestPeriod(ticketSlice[2:4])
+
+		assert.Equal(t, "early_morning", period)
+		assert.Equal(t, 1, count)
+		assert.NoError(t, err)
+	})
+}
+
+func TestSentinelErrors(t *testing.T) {
+	filename := "./ticket_test_2.csv"
+	ticketSlice, _ := ExtractTicketData(filename)
+
+	t.Run("Destination not found", func(t *testing.T) {
+		_, errTotal := GetTotalTicketsByDestination(ticketSlice, "The Moon")
+		_, errFold := GetTotalTicketsByDestinationFold(ticketSlice, "The Moon")
+		_, errFilter := FilterByDestination(ticketSlice, "The Moon")
+		_, errAverage := AverageDestination(ticketSlice, "The Moon")
+		_, errStore := NewTicketStore(ticketSlice).TotalByDestination("The Moon")
+
+		for _, err := range []error{errTotal, errFold, errFilter, errAverage, errStore} {
+			assert.ErrorIs(t, err, ErrDestinationNotFound)
+			assert.EqualError(t, err, "no tickets found for destination The Moon")
+		}
+	})
+
+	t.Run("Empty data", func(t *testing.T) {
+		_, errTotal := GetTotalTicketsByDestination(nil, "China")
+		_, errPeriod := GetCountByPeriod(nil)
+		_, errAverage := GetAveragePrice(nil)
+
+		for _, err := range []error{errTotal, errPeriod, errAverage} {
+			assert.ErrorIs(t, err, ErrEmptyData)
+			assert.NotErrorIs(t, err, ErrDestinationNotFound)
+		}
+	})
+
+	t.Run("Empty file", func(t *testing.T) {
+		_, errExtract := ExtractTicketData("./empty_ticket_test.csv")
+		_, errReader := ExtractTicketDataFromReader(strings.NewReader(""))
+
+		assert.ErrorIs(t, errExtract, ErrEmptyFile)
+		assert.ErrorIs(t, errReader, ErrEmptyFile)
+	})
+
+	t.Run("Invalid time range", func(t *testing.T) {
+		start, _ := time.Parse("15:04", "18:00")
+		end, _ := time.Parse("15:04", "10:00")
+
+		_, errWindow := GetTicketsInTimeWindow(ticketSlice, start, end)
+		_, errLimits := checkTimeBetweenLimits(start, start, end, true, true)
+
+		assert.ErrorIs(t, errWindow, ErrInvalidTimeRange)
+		assert.ErrorIs(t, errLimits, ErrInvalidTimeRange)
+	})
+}
+
+func TestGetAveragePriceByDestination(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		averages, err := GetAveragePriceByDestination(ticketSlice)
+
+		assert.Nil(t, averages)
+		assert.ErrorIs(t, err, ErrEmptyData)
+	})
+
+	t.Run("Search in valid ticket slice", func(t *testing.T) {
+		filename := "./ticket_test_2.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		expectedAverages := map[string]float64{
+			"Finland":  785,
+			"China":    558, // (537 + 579) / 2
+			"Mongolia": 1238,
+		}
+
+		averages, err := GetAveragePriceByDestination(ticketSlice)
+
+		assert.Equal(t, expectedAverages, averages)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPriceHistogram(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		histogram, err := PriceHistogram(ticketSlice, 100)
+
+		assert.Nil(t, histogram)
+		assert.ErrorIs(t, err, ErrEmptyData)
+	})
+
+	t.Run("Invalid bucket size", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		for _, bucketSize := range []int{0, -100} {
+			histogram, err := PriceHistogram(ticketSlice, bucketSize)
+
+			assert.Nil(t, histogram, bucketSize)
+			assert.EqualError(t, err, fmt.Sprintf("bucket size must be positive, got %d", bucketSize))
+		}
+	})
+
+	t.Run("Prices spanning several buckets", func(t *testing.T) {
+		filename := "./ticket_test_3.csv"
+		ticketSlice, _ := ExtractTicketData(filename)
+
+		// Prices 785, 537, 600, 1238 and 400
+		expectedHistogram := map[int]int{
+			400:  2,
+			600:  2,
+			1200: 1,
+		}
+
+		histogram, err := PriceHistogram(ticketSlice, 200)
+
+		assert.Equal(t, expectedHistogram, histogram)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Negative prices", func(t *testing.T) {
+		ticketSlice := []Ticket{
+			{id: 1, ticketPrice: -1},
+			{id: 2, ticketPrice: -100},
+			{id: 3, ticketPrice: 0},
+		}
+
+		expectedHistogram := map[int]int{-100: 2, 0: 1}
+
+		histogram, err := PriceHistogram(ticketSlice, 100)
+
+		assert.Equal(t, expectedHistogram, histogram)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDeparturesByHour(t *testing.T) {
+	t.Run("Search in empty ticket slice", func(t *testing.T) {
+		var ticketSlice []Ticket
+
+		departures, err := DeparturesByHour(ticketSlice)
+
+		assert.Nil(t, departures)
+		assert.ErrorIs(t, err, ErrEmptyData)
+	})
+
+	t.Run("Departures spread across several hours", func(t *testing.T) {
+		var ticketSlice []Ticket
+		for i, departure := range []string{"0:05", "8:30", "8:59", "13:00", "13:45", "13:59", "23:59"} {
+			departureTime, _ := time.Parse("15:04", departure)
+			ticketSlice = append(ticketSlice, Ticket{id: i + 1, departureTime: departureTime})
+		}
+
+		expectedDepartures := map[int]int{
+			0:  1,
+			8:  2,
+			13: 3,
+			23: 1,
+		}
+
+		departures, err := DeparturesByHour(ticketSlice)
+
+		assert.Equal(t, expectedDepartures, departures)
+		assert.NoError(t, err)
+	})
+}
+
+func TestMergeTickets(t *testing.T) {
+	t.Run("Merge empty ticket slices", func(t *testing.T) {
+		merged := MergeTickets(nil, nil)
+
+		assert.Empty(t, merged)
+	})
+
+	t.Run("Merge overlapping ticket slices", func(t *testing.T) {
+		a := []Ticket{
+			{id: 1, destination: "Finland", ticketPrice: 785},
+			{id: 2, destination: "China", ticketPrice: 537},
+		}
+		b := []Ticket{
+			{id: 2, destination: "Mongolia", ticketPrice: 1238},
+			{id: 3, destination: "China", ticketPrice: 579},
+			{id: 3, destination: "Brazil", ticketPrice: 400},
+		}
+		originalA := append([]Ticket{}, a...)
+		originalB := append([]Ticket{}, b...)
+
+		// The first occurrence of ids 2 and 3 is kept
+		expectedData := []Ticket{a[0], a[1], b[1]}
+
+		merged := MergeTickets(a, b)
+
+		assert.Len(t, merged, 3)
+		assert.Equal(t, expectedData, merged)
+		assert.Equal(t, originalA, a)
+		assert.Equal(t, originalB, b)
+	})
+}
+
+// benchmarkRows is the number of rows of the CSV files generated for the benchmarks.
+const benchmarkRows = 100000
+
+/*
+writeBenchmarkFile is a utility function that writes a generated CSV file with the
+specified number of rows to a temporary directory and returns its path.
+*/
+func writeBenchmarkFile(b *testing.B, rows int) string {
+	b.Helper()
+	filename := filepath.Join(b.TempDir(), "tickets.csv")
+	if err := os.WriteFile(filename, generateTicketCSV(rows), 0o644); err != nil {
+		b.Fatal(err)
+	}
+	return filename
+}
+
+func BenchmarkExtractTicketData(b *testing.B) {
+	filename := writeBenchmarkFile(b, benchmarkRows)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ExtractTicketData(filename); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetCountByPeriod(b *testing.B) {
+	ticketSlice, err := ExtractTicketDataFromReader(bytes.NewReader(generateTicketCSV(benchmarkRows)))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := GetCountByPeriod(ticketSlice); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetTotalTicketsByDestination(b *testing.B) {
+	ticketSlice, err := ExtractTicketDataFromReader(bytes.NewReader(generateTicketCSV(benchmarkRows)))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := GetTotalTicketsByDestination(ticketSlice, "China"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
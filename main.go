@@ -1,21 +1,156 @@
 package main
 
 import (
+	"bufio"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/bootcamp-go/desafio-go-bases/internal/tickets"
 )
 
+// options holds the command line options of the program.
+type options struct {
+	file        string
+	destination string
+	stat        string
+	interactive bool
+}
+
+// stats are the statistics that can be selected with the -stat flag.
+var stats = []string{"average", "count", "periods"}
+
+/*
+parseOptions parses the specified command line arguments (without the program name). The
+usage message and the parsing errors are written to output. It returns flag.ErrHelp if the
+help was requested with -h.
+*/
+func parseOptions(args []string, output io.Writer) (options, error) {
+	var opts options
+
+	flags := flag.NewFlagSet("desafio-go-bases", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&opts.file, "file", "tickets.csv", "path of the CSV file with the tickets")
+	flags.StringVar(&opts.destination, "destination", "China", "destination used by the average and count statistics")
+	flags.StringVar(&opts.stat, "stat", "average", "statistic to print: average (percentage of tickets to the "+
+		"destination), count (tickets to the destination) or periods (tickets per period of the day)")
+	flags.BoolVar(&opts.interactive, "interactive", false, "read destinations from the standard input "+
+		"and print their statistics until EOF")
+
+	if err := flags.Parse(args); err != nil {
+		return options{}, err
+	}
+
+	// Check that the statistic is known
+	for _, stat := range stats {
+		if opts.stat == stat {
+			return opts, nil
+		}
+	}
+	err := fmt.Errorf("unknown statistic %q", opts.stat)
+	fmt.Fprintln(output, err)
+	flags.Usage()
+	return options{}, err
+}
+
+// printStat writes the statistic selected in the options for the specified tickets to w.
+func printStat(data []tickets.Ticket, opts options, w io.Writer) error {
+	switch opts.stat {
+	case "average":
+		result, err := tickets.AverageDestination(data, opts.destination)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, result)
+
+	case "count":
+		result, err := tickets.GetTotalTicketsByDestination(data, opts.destination)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, result)
+
+	case "periods":
+		result, err := tickets.GetCountByPeriodTyped(data)
+		if err != nil {
+			return err
+		}
+		for _, period := range []tickets.Period{tickets.EarlyMorning, tickets.Morning, tickets.Afternoon, tickets.Evening} {
+			fmt.Fprintf(w, "%s: %d\n", period, result[period])
+		}
+
+	default:
+		return fmt.Errorf("unknown statistic %q", opts.stat)
+	}
+	return nil
+}
+
+/*
+runInteractive reads destinations from r, one per line, and writes the number of tickets
+and the percentage of the total of each one to w, until r reaches EOF. A prompt is written
+before each line is read. Empty lines are ignored and destinations without tickets are
+reported without stopping the loop.
+*/
+func runInteractive(data []tickets.Ticket, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	for {
+		fmt.Fprint(w, "destination> ")
+		if !scanner.Scan() {
+			break
+		}
+
+		destination := strings.TrimSpace(scanner.Text())
+		if destination == "" {
+			continue
+		}
+
+		// Print the statistics of the destination
+		count, err := tickets.GetTotalTicketsByDestination(data, destination)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			continue
+		}
+		average, err := tickets.AverageDestination(data, destination)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			continue
+		}
+		fmt.Fprintf(w, "%s: %d tickets (%.2f%% of the total)\n", destination, count, average)
+	}
+
+	// End the prompt line
+	fmt.Fprintln(w)
+	return scanner.Err()
+}
+
 func main() {
-	data, err := tickets.ExtractTicketData("./desafio-go-bases/tickets.csv")
+	opts, err := parseOptions(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
-		fmt.Println(err)
+		os.Exit(2)
 	}
 
-	result, err := tickets.AverageDestination(data, "China")
+	data, err := tickets.ExtractTicketData(opts.file)
 	if err != nil {
 		fmt.Println(err)
-	} else {
-		fmt.Println(result)
+		os.Exit(1)
+	}
+
+	if opts.interactive {
+		if err := runInteractive(data, os.Stdin, os.Stdout); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := printStat(data, opts, os.Stdout); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
 }
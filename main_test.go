@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/bootcamp-go/desafio-go-bases/internal/tickets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOptions(t *testing.T) {
+	t.Run("Parse without arguments", func(t *testing.T) {
+		var output bytes.Buffer
+
+		expectedOptions := options{
+			file:        "tickets.csv",
+			destination: "China",
+			stat:        "average",
+		}
+
+		opts, err := parseOptions(nil, &output)
+
+		assert.Equal(t, expectedOptions, opts)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Parse every flag", func(t *testing.T) {
+		var output bytes.Buffer
+		args := []string{"-file", "tickets.csv", "-destination", "Finland", "-stat", "periods"}
+
+		expectedOptions := options{file: "tickets.csv", destination: "Finland", stat: "periods"}
+
+		opts, err := parseOptions(args, &output)
+
+		assert.Equal(t, expectedOptions, opts)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Parse an unknown statistic", func(t *testing.T) {
+		var output bytes.Buffer
+
+		opts, err := parseOptions([]string{"-stat", "median"}, &output)
+
+		assert.Equal(t, options{}, opts)
+		assert.EqualError(t, err, `unknown statistic "median"`)
+		assert.Contains(t, output.String(), "Usage of desafio-go-bases")
+	})
+
+	t.Run("Parse the interactive flag", func(t *testing.T) {
+		var output bytes.Buffer
+
+		opts, err := parseOptions([]string{"-interactive"}, &output)
+
+		assert.True(t, opts.interactive)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Parse the help flag", func(t *testing.T) {
+		var output bytes.Buffer
+
+		_, err := parseOptions([]string{"-h"}, &output)
+
+		assert.ErrorIs(t, err, flag.ErrHelp)
+		assert.Contains(t, output.String(), "-destination")
+	})
+}
+
+func TestPrintStat(t *testing.T) {
+	data, _ := tickets.ExtractTicketData("./internal/tickets/ticket_test_2.csv")
+
+	t.Run("Print each statistic", func(t *testing.T) {
+		expectedOutputs := map[string]string{
+			"average": "50\n",
+			"count":   "2\n",
+			"periods": "early_morning: 1\nmorning: 1\nafternoon: 1\nevening: 1\n",
+		}
+
+		for stat, expectedOutput := range expectedOutputs {
+			var output bytes.Buffer
+
+			err := printStat(data, options{destination: "China", stat: stat}, &output)
+
+			assert.Equal(t, expectedOutput, output.String(), stat)
+			assert.NoError(t, err, stat)
+		}
+	})
+
+	t.Run("Print a statistic of a missing destination", func(t *testing.T) {
+		var output bytes.Buffer
+
+		err := printStat(data, options{destination: "The Moon", stat: "count"}, &output)
+
+		assert.Empty(t, output.String())
+		assert.Error(t, err)
+	})
+}
+
+func TestRunInteractive(t *testing.T) {
+	data, _ := tickets.ExtractTicketData("./internal/tickets/ticket_test_2.csv")
+
+	t.Run("Read two destinations until EOF", func(t *testing.T) {
+		input := strings.NewReader("China\n\nFinland\n")
+		var output bytes.Buffer
+
+		expectedOutput := "destination> China: 2 tickets (50.00% of the total)\n" +
+			"destination> " +
+			"destination> Finland: 1 tickets (25.00% of the total)\n" +
+			"destination> \n"
+
+		err := runInteractive(data, input, &output)
+
+		assert.Equal(t, expectedOutput, output.String())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Read a missing destination", func(t *testing.T) {
+		input := strings.NewReader("The Moon\nMongolia")
+		var output bytes.Buffer
+
+		expectedOutput := "destination> no tickets found for destination The Moon\n" +
+			"destination> Mongolia: 1 tickets (25.00% of the total)\n" +
+			"destination> \n"
+
+		err := runInteractive(data, input, &output)
+
+		assert.Equal(t, expectedOutput, output.String())
+		assert.NoError(t, err)
+	})
+}